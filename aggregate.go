@@ -0,0 +1,443 @@
+package tabwriter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// An Aggregation summarizes the cells of a column in each group of rows
+// collapsed by GroupBy.
+type Aggregation struct {
+	col  int
+	kind aggregationKind
+}
+
+type aggregationKind int
+
+const (
+	aggregateCount aggregationKind = iota
+	aggregateSum
+	aggregateMin
+	aggregateMax
+	aggregateMean
+)
+
+// Count outputs the number of rows in each group in column col.
+func Count(col int) Aggregation {
+	return Aggregation{col, aggregateCount}
+}
+
+// Sum outputs the sum of the numbers in column col of each group.
+func Sum(col int) Aggregation {
+	return Aggregation{col, aggregateSum}
+}
+
+// Min outputs the smallest number in column col of each group.
+func Min(col int) Aggregation {
+	return Aggregation{col, aggregateMin}
+}
+
+// Max outputs the largest number in column col of each group.
+func Max(col int) Aggregation {
+	return Aggregation{col, aggregateMax}
+}
+
+// Mean outputs the average of the numbers in column col of each group.
+func Mean(col int) Aggregation {
+	return Aggregation{col, aggregateMean}
+}
+
+// grouping describes how the rows of each flush are collapsed.
+type grouping struct {
+	col  int           // column holding the key of each row
+	aggs []Aggregation // summaries of the other columns
+}
+
+// GroupBy collapses the rows of each flush into one row per distinct value
+// in column 'col', in the order the values first appear. Each collapsed row
+// contains the key and the result of each aggregation in its column; other
+// columns are left empty. Cells that are not numbers are ignored by Sum, Min,
+// Max and Mean. If a group has no numbers in an aggregated column, its first
+// row's cell is output instead. The first row of each flush is assumed to be
+// a heading, and is not collapsed, if its cell in every aggregated column
+// contains text that is not a number, or if UseFirstRowAsHeader is enabled.
+// Raw text and group headers are not moved; the rows between them are grouped
+// separately. A negative column disables grouping. Aggregations of negative
+// columns are ignored.
+func (w *Writer) GroupBy(col int, aggs ...Aggregation) {
+	if col < 0 {
+		w.grouping = nil
+		return
+	}
+	w.grouping = &grouping{col: col}
+	for _, a := range aggs {
+		if a.col >= 0 {
+			w.grouping.aggs = append(w.grouping.aggs, a)
+		}
+	}
+}
+
+// groupLines collapses the buffered lines according to the writer's
+// grouping.
+func (w *Writer) groupLines() {
+	if w.grouping == nil {
+		return
+	}
+	w.transformRows(func(rows []line, first bool) []line {
+		var lines []line
+		if first && !w.firstRowHeader && len(rows) > 0 && w.isHeading(rows[0]) {
+			lines = append(lines, rows[0])
+			rows = rows[1:]
+		}
+		return append(lines, w.groupRows(rows)...)
+	})
+}
+
+// transformRows replaces each run of rows between raw text and group headers
+// with the rows returned by f. The first run of each flush is marked.
+func (w *Writer) transformRows(f func(rows []line, first bool) []line) {
+	var lines []line
+	for start := 0; start < len(w.lines); {
+		end := start
+		for end < len(w.lines) && w.lines[end].raw.size == 0 && w.lines[end].groups == nil {
+			end++
+		}
+		lines = append(lines, f(w.lines[start:end], start == 0)...)
+		if end < len(w.lines) {
+			lines = append(lines, w.lines[end])
+		}
+		start = end + 1
+	}
+	w.lines = lines
+}
+
+// isHeading returns true if line l has text that is not a number in every
+// aggregated column.
+func (w *Writer) isHeading(l line) bool {
+	for _, a := range w.grouping.aggs {
+		text := strings.TrimSpace(w.sortText(l, a.col))
+		if text == "" || isNumber(text) {
+			return false
+		}
+	}
+	return len(w.grouping.aggs) > 0
+}
+
+// groupRows collapses a run of rows into one row per distinct key.
+func (w *Writer) groupRows(rows []line) []line {
+	var keys []string
+	groups := make(map[string][]line)
+	for _, l := range rows {
+		key := w.sortText(l, w.grouping.col)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], l)
+	}
+
+	lines := make([]line, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		ncols := w.grouping.col + 1
+		for _, a := range w.grouping.aggs {
+			ncols = max(ncols, a.col+1)
+		}
+
+		text := make([]string, ncols)
+		text[w.grouping.col] = key
+		for _, a := range w.grouping.aggs {
+			text[a.col] = w.aggregate(a, group)
+		}
+
+		lines = append(lines, w.newLine(text))
+	}
+	return lines
+}
+
+// aggregate computes the result of aggregation a over a group of rows.
+func (w *Writer) aggregate(a Aggregation, group []line) string {
+	if a.kind == aggregateCount {
+		return strconv.Itoa(len(group))
+	}
+
+	var result float64
+	n, prec := 0, 0
+	for _, l := range group {
+		text := w.sortText(l, a.col)
+		v, ok := parseNumber(text)
+		if !ok {
+			continue
+		}
+		if i := strings.IndexByte(text, '.'); i >= 0 {
+			prec = max(prec, len(numberText(text[i+1:])))
+		}
+
+		switch {
+		case n == 0:
+			result = v
+		case a.kind == aggregateMin && v < result:
+			result = v
+		case a.kind == aggregateMax && v > result:
+			result = v
+		case a.kind == aggregateSum || a.kind == aggregateMean:
+			result += v
+		}
+		n++
+	}
+
+	if n == 0 {
+		return w.sortText(group[0], a.col)
+	}
+	if a.kind == aggregateMean {
+		result /= float64(n)
+		prec = max(prec, 2)
+	}
+	return strconv.FormatFloat(result, 'f', prec, 64)
+}
+
+// newLine returns a line containing a cell for each of the given texts,
+// omitting trailing empty cells.
+func (w *Writer) newLine(text []string) line {
+	for len(text) > 1 && text[len(text)-1] == "" {
+		text = text[:len(text)-1]
+	}
+	l := line{cells: make([]cell, len(text))}
+	for j, t := range text {
+		l.cells[j] = w.newCell(t)
+	}
+	l.cells[len(l.cells)-1].term = true
+	return l
+}
+
+// newCell appends text to the buffer and returns a cell containing it.
+func (w *Writer) newCell(text string) cell {
+	c := cell{start: w.buf.Len(), size: len(text), width: w.measure([]byte(text))}
+	w.buf.WriteString(text)
+	return c
+}
+
+// pivot describes how the rows of each flush are cross-tabulated.
+type pivot struct {
+	row   int // column holding the key of each output row
+	col   int // column holding the key of each output column
+	value int // column holding the values
+}
+
+// Pivot replaces the rows of each flush with a crosstab. Each distinct value
+// in column rowKeyCol becomes a row, each distinct value in column colKeyCol
+// becomes a column, and each cell contains the value in column valueCol of
+// the rows having both keys. The numbers of several rows having the same keys
+// are summed. Rows and columns appear in the order their keys first appear.
+// The first row of each flush is assumed to be a heading if its value is
+// text that is not a number; its row key then heads the crosstab's first
+// column. Raw text and group headers are not moved; the rows between them
+// are pivoted separately. A negative rowKeyCol disables pivoting.
+func (w *Writer) Pivot(rowKeyCol, colKeyCol, valueCol int) {
+	if rowKeyCol < 0 {
+		w.pivot = nil
+		return
+	}
+	w.pivot = &pivot{rowKeyCol, colKeyCol, valueCol}
+}
+
+// pivotLines cross-tabulates the buffered lines according to the writer's
+// pivot.
+func (w *Writer) pivotLines() {
+	if w.pivot == nil {
+		return
+	}
+	w.transformRows(w.pivotRows)
+}
+
+// pivotRows cross-tabulates a run of rows.
+func (w *Writer) pivotRows(rows []line, first bool) []line {
+	p := w.pivot
+	var corner string
+	if first && len(rows) > 0 {
+		text := strings.TrimSpace(w.sortText(rows[0], p.value))
+		if text != "" && !isNumber(text) {
+			corner = w.sortText(rows[0], p.row)
+			rows = rows[1:]
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var rowKeys, colKeys []string
+	cols := make(map[string]int)
+	cells := make(map[string]map[string][]line)
+	for _, l := range rows {
+		r, c := w.sortText(l, p.row), w.sortText(l, p.col)
+		if cells[r] == nil {
+			rowKeys = append(rowKeys, r)
+			cells[r] = make(map[string][]line)
+		}
+		if _, ok := cols[c]; !ok {
+			cols[c] = len(colKeys)
+			colKeys = append(colKeys, c)
+		}
+		cells[r][c] = append(cells[r][c], l)
+	}
+
+	lines := []line{w.newLine(append([]string{corner}, colKeys...))}
+	for _, r := range rowKeys {
+		text := make([]string, len(colKeys)+1)
+		text[0] = r
+		for c, group := range cells[r] {
+			text[cols[c]+1] = w.aggregate(Sum(p.value), group)
+		}
+		lines = append(lines, w.newLine(text))
+	}
+	return lines
+}
+
+// EnableStatsFooter appends a footer to each flush summarizing the numbers in
+// the given columns: rows labeled "min", "max" and "avg" containing the
+// smallest, largest and average number of each column, aligned with the
+// column. The labels are output in the first column unless it is one of the
+// summarized columns. Cells that are not numbers, such as headings, are
+// ignored, as are rows omitted by LimitRows. Passing no columns disables the
+// footer.
+func (w *Writer) EnableStatsFooter(cols ...int) {
+	w.statsCols = append([]int(nil), cols...)
+}
+
+// statsLines appends the stats footer to the buffered lines.
+func (w *Writer) statsLines() {
+	if len(w.statsCols) == 0 {
+		return
+	}
+
+	var rows []line
+	h := w.headerIndex()
+	for i, l := range w.lines {
+		if l.raw.size == 0 && l.groups == nil && i != h {
+			rows = append(rows, l)
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	ncols := 1
+	for _, col := range w.statsCols {
+		ncols = max(ncols, col+1)
+	}
+	stats := []struct {
+		label string
+		agg   func(col int) Aggregation
+	}{
+		{"min", Min},
+		{"max", Max},
+		{"avg", Mean},
+	}
+	for _, s := range stats {
+		text := make([]string, ncols)
+		text[0] = s.label
+		for _, col := range w.statsCols {
+			for _, l := range rows {
+				if isNumber(w.sortText(l, col)) {
+					text[col] = w.aggregate(s.agg(col), rows)
+					break
+				}
+			}
+		}
+		w.lines = append(w.lines, w.newLine(text))
+	}
+}
+
+// histogram describes how the rows of each flush are bucketed.
+type histogram struct {
+	col      int // column holding the values
+	buckets  int // number of buckets
+	barWidth int // width of the longest bar
+}
+
+// Histogram replaces the rows of each flush with the distribution of the
+// numbers in column 'col'. The range of the numbers is divided into the given
+// number of equal buckets, and each bucket becomes a row containing its
+// bounds, the number of values it contains and a bar drawn by Bar, the
+// longest of which is barWidth columns wide. Cells that are not numbers are
+// ignored. The first row of each flush is assumed to be a heading if its cell
+// is text that is not a number; it is then replaced by a heading row naming
+// the bucket, count and distribution columns. Raw text and group headers are
+// not moved; the rows between them are bucketed separately. A negative col
+// disables the histogram.
+func (w *Writer) Histogram(col, buckets, barWidth int) {
+	if col < 0 {
+		w.histogram = nil
+		return
+	}
+	w.histogram = &histogram{col, max(buckets, 1), barWidth}
+}
+
+// histogramLines buckets the buffered lines according to the writer's
+// histogram.
+func (w *Writer) histogramLines() {
+	if w.histogram == nil {
+		return
+	}
+	w.transformRows(w.histogramRows)
+}
+
+// histogramRows buckets a run of rows.
+func (w *Writer) histogramRows(rows []line, first bool) []line {
+	h := w.histogram
+	var lines []line
+	if first && len(rows) > 0 {
+		text := strings.TrimSpace(w.sortText(rows[0], h.col))
+		if text != "" && !isNumber(text) {
+			lines = append(lines, w.newLine([]string{text, "count", "distribution"}))
+			rows = rows[1:]
+		}
+	}
+
+	var values []float64
+	prec := 0
+	for _, l := range rows {
+		text := w.sortText(l, h.col)
+		if v, ok := parseNumber(text); ok {
+			values = append(values, v)
+			if i := strings.IndexByte(text, '.'); i >= 0 {
+				prec = max(prec, len(numberText(text[i+1:])))
+			}
+		}
+	}
+	if len(values) == 0 {
+		return lines
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	step := (hi - lo) / float64(h.buckets)
+	counts := make([]int, h.buckets)
+	for _, v := range values {
+		i := h.buckets - 1
+		if step > 0 {
+			i = min(int((v-lo)/step), h.buckets-1)
+		}
+		counts[i]++
+	}
+
+	most := 0
+	for _, n := range counts {
+		most = max(most, n)
+	}
+	bar := Bar(h.barWidth, float64(most))
+	format := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', prec, 64)
+	}
+	for i, n := range counts {
+		label := "[" + format(lo+float64(i)*step) + ", " + format(lo+float64(i+1)*step) + ")"
+		if i == h.buckets-1 {
+			label = "[" + format(lo+float64(i)*step) + ", " + format(hi) + "]"
+		}
+		count := strconv.Itoa(n)
+		lines = append(lines, w.newLine([]string{label, count, bar(count)}))
+	}
+	return lines
+}
@@ -0,0 +1,92 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.GroupBy(0, Count(1), Sum(2), Max(3), Mean(4))
+	fmt.Fprint(w, "service\trequests\terrors\tp99\tload\n")
+	fmt.Fprint(w, "api\t\t3\t120\t0.5\n")
+	fmt.Fprint(w, "web\t\t1,200\t80\t1\n")
+	fmt.Fprint(w, "api\t\t4\t95.5\t0.25\n")
+	fmt.Fprint(w, "db\t\tn/a\t-\t2\n")
+	w.Flush()
+
+	want := "service requests errors p99   load\n" +
+		"api     2        7      120.0 0.38\n" +
+		"web     1        1200   80    1.00\n" +
+		"db      1        n/a    -     2.00\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestPivot(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.Pivot(0, 1, 2)
+	fmt.Fprint(w, "service\tregion\terrors\n")
+	fmt.Fprint(w, "api\tus\t3\n")
+	fmt.Fprint(w, "api\teu\t1\n")
+	fmt.Fprint(w, "web\tus\t5\n")
+	fmt.Fprint(w, "api\tus\t2\n")
+	fmt.Fprint(w, "db\tap\t7\n")
+	w.Flush()
+
+	want := "service us eu ap\n" +
+		"api     5  1\n" +
+		"web     5\n" +
+		"db        7\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestEnableStatsFooter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, AlignRight)
+	w.SetColumnFormat(2, 0, 1, AlignRight)
+	w.EnableStatsFooter(1, 2)
+	fmt.Fprint(w, "host\tlatency\tload\tregion\n")
+	fmt.Fprint(w, "alpha\t12\t0.5\tus\n")
+	fmt.Fprint(w, "beta\t40\t1.25\teu\n")
+	fmt.Fprint(w, "gamma\t-\t2\tus\n")
+	w.Flush()
+
+	want := "host  latency load region\n" +
+		"alpha      12  0.5 us\n" +
+		"beta       40 1.25 eu\n" +
+		"gamma       -    2 us\n" +
+		"min        12 0.50\n" +
+		"max        40 2.00\n" +
+		"avg     26.00 1.25\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, AlignRight)
+	w.Histogram(1, 3, 4)
+	fmt.Fprint(w, "request\tms\n")
+	for i, ms := range []string{"10", "12", "25", "11", "40", "n/a", "14"} {
+		fmt.Fprintf(w, "r%d\t%s\n", i, ms)
+	}
+	w.Flush()
+
+	want := "ms       count distribution\n" +
+		"[10, 20)     4 ████\n" +
+		"[20, 30)     1 █\n" +
+		"[30, 40]     1 █\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
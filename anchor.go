@@ -0,0 +1,58 @@
+package tabwriter
+
+import "bytes"
+
+// Anchor separates the two parts of a cell that are anchored to opposite
+// edges of the cell's column: the text before it is aligned left and the
+// text after it is aligned right, regardless of the column's alignment. The
+// space between the parts is filled with the column's pad character, which
+// is set with SetColumnPadChar, or with spaces if the column is padded with
+// tabs. For example, with '.' as the column's pad character, the cell
+// "name \x1f 42%" may be output as
+//
+//	name ........ 42%
+//
+// Formats that don't align columns, such as FormatTSV, output the separator
+// as a space.
+const Anchor = '\x1f'
+
+// Anchored returns the text of a cell whose left and right parts are
+// anchored to opposite edges of its column.
+func Anchored(left, right string) string {
+	return left + string(Anchor) + right
+}
+
+// anchorCell fills the space between the parts of an anchored cell's text,
+// given the padding and format writeCell would output it with. It returns
+// the filled text, the padding remaining after it, which is the column's
+// padding, and the format to output it with, which aligns it left. The text
+// of cells that aren't anchored is returned unchanged.
+func (w *Writer) anchorCell(text []byte, padding int, format format) ([]byte, int, format) {
+	i := bytes.IndexByte(text, Anchor)
+	if i < 0 {
+		return text, padding, format
+	}
+
+	fill := format.padchar
+	if fill == 0 {
+		fill = w.padchar
+	}
+	if fill == '\t' {
+		fill = ' '
+	}
+
+	// The separator counts as one column of the cell's text.
+	n := max(padding-format.padding, 0) + 1
+	filled := make([]byte, 0, len(text)+n)
+	filled = append(filled, text[:i]...)
+	filled = append(filled, bytes.Repeat([]byte{fill}, n)...)
+	filled = append(filled, text[i+1:]...)
+	format.flags &^= AlignRight | AlignCenter
+	return filled, min(padding, format.padding), format
+}
+
+// unanchor returns text with the separators of anchored cells replaced by
+// spaces.
+func unanchor(text []byte) string {
+	return string(bytes.ReplaceAll(text, []byte{Anchor}, space))
+}
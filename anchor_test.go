@@ -0,0 +1,32 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestAnchored(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnPadChar(1, '.')
+	fmt.Fprint(w, "disk\t"+Anchored("/ ", " 42%")+"\tok\n")
+	fmt.Fprint(w, "disk\t"+Anchored("/var/lib/data ", " 7%")+"\tok\n")
+	fmt.Fprint(w, "cpu\tload\tok\n")
+	w.Flush()
+
+	want := "disk / ............ 42%.ok\n" +
+		"disk /var/lib/data . 7%.ok\n" +
+		"cpu  load...............ok\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	w.SetOutputFormat(FormatTSV)
+	fmt.Fprint(w, "disk\t"+Anchored("/", "42%")+"\n")
+	w.Flush()
+	if got := b.String(); got != "disk\t/ 42%\n" {
+		t.Errorf("tsv: got %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package tabwriter
+
+import (
+	"bytes"
+	"strings"
+)
+
+// blockClean replaces the characters of blocks that would be interpreted as
+// table structure, other than tabs and line breaks.
+var blockClean = strings.NewReplacer("\r\n", "\n", "\v", " ", "\r", " ", "\f", " ")
+
+// WriteBlock writes a pre-rendered block of text, such as a code snippet, to
+// the cell currently being written. The block is output verbatim: its lines
+// are output one below the other, its tabs are expanded to spaces using the
+// writer's tab width, and it is neither rendered, collapsed, wrapped nor
+// truncated by the column's settings. The column is at least as wide as the
+// block's longest line, and the row is expanded vertically to fit the block,
+// as with the cells written by WriteRow. The cell is terminated by the next
+// tab or line break written, as usual.
+func (w *Writer) WriteBlock(text string) {
+	w.addTextToCell([]byte(w.expandTabs(blockClean.Replace(text))))
+	w.cell.block = true
+}
+
+// expandTabs replaces the tabs in text with spaces reaching the next tab
+// stop of the writer's tab width, or with a single space if the tab width
+// is not positive.
+func (w *Writer) expandTabs(text string) string {
+	if !strings.Contains(text, "\t") {
+		return text
+	}
+
+	var b bytes.Buffer
+	col := 0
+	for len(text) > 0 {
+		i := strings.IndexAny(text, "\t\n")
+		if i < 0 {
+			b.WriteString(text)
+			break
+		}
+		b.WriteString(text[:i])
+		col += w.measure([]byte(text[:i]))
+		if text[i] == '\n' {
+			b.WriteByte('\n')
+			col = 0
+		} else {
+			n := 1
+			if w.tabwidth > 0 {
+				n = w.tabwidth - col%w.tabwidth
+			}
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+		}
+		text = text[i+1:]
+	}
+	return b.String()
+}
+
+// addBlockToLine appends the working cell, a pre-rendered block, to line
+// without applying the column's settings.
+func (w *Writer) addBlockToLine(l *line) {
+	w.cell.start = w.buf.Len() - w.cell.size
+	w.splitCell(&w.cell, wrap{})
+	l.cells = append(l.cells, w.cell)
+	w.cell = cell{}
+}
@@ -0,0 +1,75 @@
+package tabwriter
+
+// A BorderStyle holds the characters drawing the grid of the border output
+// format. Each character should occupy a single column.
+type BorderStyle struct {
+	Horizontal string // horizontal rules
+	Vertical   string // vertical rules
+
+	TopLeft     string // top left corner
+	TopRight    string // top right corner
+	BottomLeft  string // bottom left corner
+	BottomRight string // bottom right corner
+
+	TopJunction    string // vertical rules meeting the top rule
+	BottomJunction string // vertical rules meeting the bottom rule
+	LeftJunction   string // horizontal rules meeting the left edge
+	RightJunction  string // horizontal rules meeting the right edge
+	Cross          string // horizontal and vertical rules crossing
+}
+
+// Presets of border styles.
+var (
+	// BorderASCII draws the grid with ASCII characters only, which suits
+	// environments that cannot display box-drawing characters.
+	BorderASCII = BorderStyle{"-", "|", "+", "+", "+", "+", "+", "+", "+", "+", "+"}
+
+	// BorderLight draws the grid with light lines. It is the default style.
+	BorderLight = BorderStyle{"─", "│", "┌", "┐", "└", "┘", "┬", "┴", "├", "┤", "┼"}
+
+	// BorderHeavy draws the grid with heavy lines.
+	BorderHeavy = BorderStyle{"━", "┃", "┏", "┓", "┗", "┛", "┳", "┻", "┣", "┫", "╋"}
+
+	// BorderDouble draws the grid with double lines.
+	BorderDouble = BorderStyle{"═", "║", "╔", "╗", "╚", "╝", "╦", "╩", "╠", "╣", "╬"}
+
+	// BorderRounded draws the grid with light lines and rounded corners.
+	BorderRounded = BorderStyle{"─", "│", "╭", "╮", "╰", "╯", "┬", "┴", "├", "┤", "┼"}
+)
+
+// SetBorderStyle sets the characters drawing the grid of the border output
+// format, such as one of the presets BorderASCII, BorderLight, BorderHeavy,
+// BorderDouble and BorderRounded.
+func (w *Writer) SetBorderStyle(style BorderStyle) {
+	w.borderStyle = style
+}
+
+// Rules select the rules drawn by the border output format.
+type Rules uint
+
+const (
+	// RuleOuter frames the table.
+	RuleOuter Rules = 1 << iota
+
+	// RuleColumns separates the columns with vertical rules.
+	RuleColumns
+
+	// RuleHeader separates the first row, the table's header, from the
+	// other rows with a horizontal rule.
+	RuleHeader
+
+	// RuleRows separates all of the rows with horizontal rules.
+	RuleRows
+
+	// RuleAll draws all of the rules.
+	RuleAll = RuleOuter | RuleColumns | RuleHeader | RuleRows
+)
+
+// SetBorderRules selects the rules drawn by the border output format, such
+// as RuleOuter|RuleHeader for a frame with a header underline, or RuleHeader
+// alone for just the underline. The default is RuleOuter|RuleColumns. When a
+// table has no outer frame, its first column is output at the start of the
+// line and its last column isn't padded.
+func (w *Writer) SetBorderRules(rules Rules) {
+	w.borderRules = rules
+}
@@ -0,0 +1,180 @@
+// Tabfmt aligns the columns of tab-separated, CSV or JSON text read from
+// standard input and writes the result to standard output.
+//
+// Usage:
+//
+//	tabfmt [flags] < input
+//
+// The flags are:
+//
+//	-csv         read CSV input instead of tab-separated input
+//	-json        read a JSON array of objects instead of tab-separated
+//	             input; the objects' keys form the first row
+//	-format f    output format: text, markdown, border, html, json,
+//	             accessible or tsv (default text)
+//	-minwidth n  minimal cell width including padding
+//	-padding n   extra pad characters added to cells (default 1)
+//	-padchar c   character to use for padding (default ' ')
+//	-right cols  comma-separated list of columns to align right
+//	-wrap specs  comma-separated list of col=width pairs; wraps the text of
+//	             each column at the given width
+//	-max specs   comma-separated list of col=width pairs; truncates the text
+//	             of each column at the given width
+//	-spark specs comma-separated list of col=width pairs; renders each
+//	             column's comma-separated numbers as a sparkline
+//
+// Columns are numbered from 0. For example, the following command formats a
+// CSV file as a markdown table with a right-aligned third column:
+//
+//	tabfmt -csv -format markdown -right 2 < report.csv
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/beevik/tabwriter"
+)
+
+var (
+	csvInput  = flag.Bool("csv", false, "read CSV input instead of tab-separated input")
+	jsonInput = flag.Bool("json", false, "read a JSON array of objects instead of tab-separated input")
+	format    = flag.String("format", "text", "output format: text, markdown, border, html, json, accessible or tsv")
+	minwidth  = flag.Int("minwidth", 0, "minimal cell width including padding")
+	padding   = flag.Int("padding", 1, "extra pad characters added to cells")
+	padchar   = flag.String("padchar", " ", "character to use for padding")
+	right     = flag.String("right", "", "comma-separated list of columns to align right")
+	wrap      = flag.String("wrap", "", "comma-separated col=width pairs of columns to wrap")
+	trunc     = flag.String("max", "", "comma-separated col=width pairs of columns to truncate")
+	spark     = flag.String("spark", "", "comma-separated col=width pairs of columns to render as sparklines")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: tabfmt [flags] < input\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(os.Stdout, os.Stdin); err != nil {
+		fmt.Fprintf(os.Stderr, "tabfmt: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dst io.Writer, src io.Reader) error {
+	w, err := newWriter(dst)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *csvInput:
+		err = w.FromCSV(src)
+	case *jsonInput:
+		err = w.FromJSON(src)
+	default:
+		_, err = io.Copy(w, bufio.NewReader(src))
+	}
+	if err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// newWriter creates a tabwriter configured according to the command's
+// flags.
+func newWriter(dst io.Writer) (*tabwriter.Writer, error) {
+	if len(*padchar) != 1 {
+		return nil, errors.New("padchar must be a single character")
+	}
+	w := tabwriter.NewWriter(dst, *minwidth, 8, *padding, (*padchar)[0], 0)
+
+	var f tabwriter.OutputFormat
+	if err := f.UnmarshalText([]byte(*format)); err != nil {
+		return nil, err
+	}
+	w.SetOutputFormat(f)
+
+	cols, err := parseColumns(*right)
+	if err != nil {
+		return nil, fmt.Errorf("right: %v", err)
+	}
+	for _, col := range cols {
+		w.SetColumnFormat(col, *minwidth, *padding, tabwriter.AlignRight)
+	}
+
+	err = forEachSpec(*wrap, func(col, width int) {
+		w.SetColumnWrap(col, width, tabwriter.BreakWord)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wrap: %v", err)
+	}
+
+	err = forEachSpec(*trunc, func(col, width int) {
+		w.SetColumnMaxWidth(col, width)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("max: %v", err)
+	}
+
+	err = forEachSpec(*spark, func(col, width int) {
+		w.SetColumnRenderer(col, tabwriter.Sparkline(width))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("spark: %v", err)
+	}
+
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// parseColumns parses a comma-separated list of column numbers.
+func parseColumns(list string) ([]int, error) {
+	var cols []int
+	for _, s := range strings.Split(list, ",") {
+		if s == "" {
+			continue
+		}
+		col, err := strconv.Atoi(s)
+		if err != nil || col < 0 {
+			return nil, fmt.Errorf("invalid column %q", s)
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// forEachSpec parses a comma-separated list of col=width pairs and calls fn
+// for each pair.
+func forEachSpec(list string, fn func(col, width int)) error {
+	for _, s := range strings.Split(list, ",") {
+		if s == "" {
+			continue
+		}
+		i := strings.IndexByte(s, '=')
+		if i < 0 {
+			return fmt.Errorf("invalid spec %q, want col=width", s)
+		}
+		col, err1 := strconv.Atoi(s[:i])
+		width, err2 := strconv.Atoi(s[i+1:])
+		if err1 != nil || err2 != nil || col < 0 || width < 1 {
+			return fmt.Errorf("invalid spec %q, want col=width", s)
+		}
+		fn(col, width)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package tabwriter
+
+import (
+	"io"
+	"sync"
+)
+
+// Config describes the settings used to initialize a tabwriter.Writer.
+type Config struct {
+	MinWidth          int    // minimal cell width including padding
+	TabWidth          int    // width of a tab in spaces
+	Padding           int    // extra pad characters added to cells
+	PadChar           byte   // character to use for padding
+	Flags             uint   // formatting control flags
+	DescriptionIndent int    // columns to indent descriptions
+	DescriptionWrap   int    // column at which to word-wrap descriptions
+	RowWrap           int    // column at which to wrap overlong rows
+	RowPrefix         string // prefix for continuations of wrapped rows
+}
+
+var (
+	defaultsMu sync.RWMutex
+	defaults   = Config{
+		MinWidth:          0,
+		TabWidth:          8,
+		Padding:           1,
+		PadChar:           ' ',
+		Flags:             0,
+		DescriptionIndent: 8,
+		DescriptionWrap:   72,
+		RowWrap:           0,
+		RowPrefix:         "",
+	}
+)
+
+// SetDefaults establishes the package-wide default configuration. Writers
+// created by New use all of its settings, while writers created by NewWriter
+// or Init use its description and row settings. Each writer's settings may still be
+// overridden individually after it has been created.
+func SetDefaults(cfg Config) {
+	defaultsMu.Lock()
+	defaults = cfg
+	defaultsMu.Unlock()
+}
+
+// Defaults returns the package-wide default configuration.
+func Defaults() Config {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+	return defaults
+}
+
+// New creates and initializes a new tabwriter.Writer using the package-wide
+// default configuration.
+func New(output io.Writer) *Writer {
+	return NewWriterConfig(output, Defaults())
+}
+
+// NewWriterConfig creates and initializes a new tabwriter.Writer using the
+// settings in cfg.
+func NewWriterConfig(output io.Writer, cfg Config) *Writer {
+	w := NewWriter(output, cfg.MinWidth, cfg.TabWidth, cfg.Padding, cfg.PadChar, cfg.Flags)
+	w.SetDescriptionFormat(cfg.DescriptionIndent, cfg.DescriptionWrap)
+	w.SetRowFormat(cfg.RowWrap, cfg.RowPrefix)
+	return w
+}
+
+// TabWidth returns the width of a tab in spaces.
+func (w *Writer) TabWidth() int {
+	return w.tabwidth
+}
+
+// PadChar returns the character used for padding.
+func (w *Writer) PadChar() byte {
+	return w.padchar
+}
+
+// ColumnFormat returns the format settings used for column 'col', which are
+// the writer's default settings unless SetColumnFormat has been called for
+// the column.
+func (w *Writer) ColumnFormat(col int) (minwidth, padding int, flags uint) {
+	f := w.columnFormat(col)
+	return f.minwidth, f.padding, f.flags
+}
+
+// DescriptionFormat returns the format settings for description output, as
+// set by SetDescriptionFormat.
+func (w *Writer) DescriptionFormat() (indent, wordwrap int) {
+	return w.formatDescription.indent, w.formatDescription.wordwrap
+}
+
+// RowFormat returns the format settings for overlong rows, as set by
+// SetRowFormat.
+func (w *Writer) RowFormat() (wrap int, prefix string) {
+	return w.formatRow.wrap, w.formatRow.prefix
+}
+
+// ColumnCount returns the number of columns of the rows written since the
+// last flush, including the row currently being written.
+func (w *Writer) ColumnCount() int {
+	n := 0
+	for _, l := range w.lines {
+		n = max(n, len(l.cells))
+	}
+	if !w.descmode && w.cell.size > 0 {
+		n = max(n, len(w.lines[len(w.lines)-1].cells)+1)
+	}
+	return n
+}
@@ -0,0 +1,114 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestSetDefaults(t *testing.T) {
+	saved := Defaults()
+	defer SetDefaults(saved)
+
+	SetDefaults(Config{
+		MinWidth:          0,
+		TabWidth:          8,
+		Padding:           1,
+		PadChar:           '.',
+		Flags:             AlignRight,
+		DescriptionIndent: 2,
+		DescriptionWrap:   40,
+	})
+
+	var b1, b2 bytes.Buffer
+	w1 := New(&b1)
+	fmt.Fprint(w1, "a\t10\tx\rdesc\n")
+	fmt.Fprint(w1, "bbb\t1\tx\n")
+	w1.Flush()
+
+	want := "..a.10.x\n" +
+		"..desc\n" +
+		"bbb..1.x\n"
+	if b1.String() != want {
+		t.Errorf("New: got:\n%s\nwant:\n%s", b1.String(), want)
+	}
+
+	w2 := NewWriter(&b2, 0, 8, 1, ' ', 0)
+	fmt.Fprint(w2, "a\tb\rdesc\n")
+	w2.Flush()
+
+	want = "a b\n" +
+		"  desc\n"
+	if b2.String() != want {
+		t.Errorf("NewWriter: got:\n%s\nwant:\n%s", b2.String(), want)
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 2, 4, 1, '.', 0)
+	w.SetColumnFormat(1, 0, 3, AlignRight)
+	w.SetDescriptionFormat(6, 60)
+	w.SetRowFormat(80, "> ")
+
+	if got := w.TabWidth(); got != 4 {
+		t.Errorf("TabWidth: got %d, want 4", got)
+	}
+	if got := w.PadChar(); got != '.' {
+		t.Errorf("PadChar: got %q, want '.'", got)
+	}
+	if m, p, f := w.ColumnFormat(0); m != 2 || p != 1 || f != 0 {
+		t.Errorf("ColumnFormat(0): got %d, %d, %d", m, p, f)
+	}
+	if m, p, f := w.ColumnFormat(1); m != 0 || p != 3 || f != AlignRight {
+		t.Errorf("ColumnFormat(1): got %d, %d, %d", m, p, f)
+	}
+	if indent, wrap := w.DescriptionFormat(); indent != 6 || wrap != 60 {
+		t.Errorf("DescriptionFormat: got %d, %d", indent, wrap)
+	}
+	if wrap, prefix := w.RowFormat(); wrap != 80 || prefix != "> " {
+		t.Errorf("RowFormat: got %d, %q", wrap, prefix)
+	}
+
+	fmt.Fprint(w, "a\tb\nc\td\te")
+	if got := w.ColumnCount(); got != 3 {
+		t.Errorf("ColumnCount: got %d, want 3", got)
+	}
+	w.Flush()
+	if got := w.ColumnCount(); got != 0 {
+		t.Errorf("ColumnCount after Flush: got %d, want 0", got)
+	}
+}
+
+func TestSetPadChar(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, '.', 0)
+	fmt.Fprint(w, "a\tbb\tc\n")
+	fmt.Fprint(w, "aaa\tb\tc\n")
+	w.Flush()
+
+	w.SetPadChar('\t')
+	w.SetTabWidth(4)
+	fmt.Fprint(w, "a\tbb\tc\n")
+	w.SetPadChar(' ')
+	fmt.Fprint(w, "aaaaa\tb\tc\n")
+	w.Flush()
+
+	w.SetPadChar('\t')
+	fmt.Fprint(w, "aaaaa\tb\tc\n")
+	fmt.Fprint(w, "a\tbb\tc\n")
+	w.Flush()
+
+	want := "a...bb.c\n" +
+		"aaa.b..c\n" +
+		"a     bb c\n" +
+		"aaaaa b  c\n" +
+		"aaaaa\tb\tc\n" +
+		"a\t\tbb\tc\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+	if w.PadChar() != '\t' || w.TabWidth() != 4 {
+		t.Errorf("got padchar %q and tab width %d", w.PadChar(), w.TabWidth())
+	}
+}
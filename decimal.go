@@ -0,0 +1,127 @@
+package tabwriter
+
+import (
+	"bytes"
+	"strings"
+)
+
+// SetDecimalSeparator sets the decimal separator of the numbers in columns
+// aligned with AlignDecimal, such as ',' for numbers written as "1.234,5".
+// The default separator is '.'.
+func (w *Writer) SetDecimalSeparator(sep rune) {
+	w.decimalSep = sep
+}
+
+// decimalSeparator returns the writer's decimal separator.
+func (w *Writer) decimalSeparator() rune {
+	if w.decimalSep == 0 {
+		return '.'
+	}
+	return w.decimalSep
+}
+
+// alignDecimals pads the numbers in columns aligned with AlignDecimal or
+// AlignNumeric with trailing spaces, so that their decimal separators line up
+// once the columns are right-aligned. Export formats keep their numbers unpadded.
+func (w *Writer) alignDecimals() {
+	switch w.flushFormat {
+	case FormatHTML, FormatJSON, FormatAccessible, FormatTSV:
+		return
+	}
+
+	fractions := make(map[int]int)
+	w.forEachDecimal(func(col int, c *cell, fraction int) {
+		fractions[col] = max(fractions[col], fraction)
+	})
+	w.padDecimals(fractions)
+}
+
+// padDecimals pads the numbers in the buffered lines with trailing spaces,
+// so that their fractions are as wide as the widest of their column's, given
+// by fractions.
+func (w *Writer) padDecimals(fractions map[int]int) {
+	w.forEachDecimal(func(col int, c *cell, fraction int) {
+		if pad := fractions[col] - fraction; pad > 0 {
+			text := append(append([]byte(nil), w.cellText(*c)...), bytes.Repeat(space, pad)...)
+			c.start, c.size = w.buf.Len(), len(text)
+			c.width += pad
+			w.buf.Write(text)
+		}
+	})
+}
+
+// alignsDecimals returns true if any of the writer's columns is aligned with
+// AlignDecimal or AlignNumeric.
+func (w *Writer) alignsDecimals() bool {
+	if w.format.flags&(AlignDecimal|AlignNumeric) != 0 {
+		return true
+	}
+	for _, f := range w.formatColumn {
+		if f.flags&specified != 0 && f.flags&(AlignDecimal|AlignNumeric) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachDecimal calls fn for each unwrapped cell containing a number in the
+// columns aligned with AlignDecimal or AlignNumeric, along with the width of
+// the number's fraction, including its decimal separator and, in columns
+// aligned with AlignNumeric, its trailing annotation.
+func (w *Writer) forEachDecimal(fn func(col int, c *cell, fraction int)) {
+	sep := string(w.decimalSeparator())
+	for i := range w.lines {
+		l := &w.lines[i]
+		if l.raw.size > 0 || l.groups != nil {
+			continue
+		}
+		for j := range l.cells {
+			c := &l.cells[j]
+			flags := w.getFormat(j).flags
+			if flags&(AlignDecimal|AlignNumeric) == 0 || c.segs != nil {
+				continue
+			}
+			text := string(w.cellText(*c))
+			fraction := 0
+			switch {
+			case flags&AlignNumeric != 0:
+				k := w.integerEnd(text)
+				if k < 0 {
+					continue
+				}
+				fraction = w.measure([]byte(text[k:]))
+			case !strings.ContainsAny(text, "0123456789"):
+				continue
+			default:
+				if k := strings.LastIndex(text, sep); k >= 0 {
+					fraction = w.measure([]byte(text[k:]))
+				}
+			}
+			fn(j, c, fraction)
+		}
+	}
+}
+
+// integerEnd returns the index of the end of the integer part of the number
+// that text begins with, or -1 if text doesn't begin with a number. The
+// number may have a sign and digits grouped by the separator other than the
+// writer's decimal separator, as in "-1,234".
+func (w *Writer) integerEnd(text string) int {
+	group := byte(',')
+	if w.decimalSeparator() == ',' {
+		group = '.'
+	}
+
+	i := 0
+	if i < len(text) && (text[i] == '+' || text[i] == '-') {
+		i++
+	}
+	start := i
+	for i < len(text) && (isDigit(text[i]) || text[i] == group && i > start && i+1 < len(text) && isDigit(text[i+1])) {
+		i++
+	}
+	if i == start {
+		return -1
+	}
+	return i
+}
@@ -0,0 +1,94 @@
+package tabwriter
+
+// DiffStyles holds the styles emphasizing the rows of a diff written by
+// WriteDiff. The zero Style leaves rows unemphasized.
+type DiffStyles struct {
+	Added   Style // emphasizes rows present only in the new table
+	Removed Style // emphasizes rows present only in the old table
+	Changed Style // emphasizes rows whose cells differ
+}
+
+// DiffColors colors added rows green, removed rows red and changed rows
+// yellow.
+var DiffColors = DiffStyles{Added: Green, Removed: Red, Changed: Yellow}
+
+// WriteDiff writes the differences between two tables as rows of the writer.
+// Rows are matched by the text of their cell in column keyCol, which should
+// be unique within each table. Each row is preceded by a marker cell: "+"
+// for rows added in newRows, "-" for rows removed from oldRows, "~" for rows
+// whose cells changed and a space for unchanged rows. The changed cells of a
+// row contain both texts, as in "old → new". Rows are written in the order of
+// newRows, with removed rows at their position in oldRows. Rows are
+// emphasized with the given styles. A partially written row is terminated
+// first. Like any rows, the diff is aligned on the next flush.
+func (w *Writer) WriteDiff(oldRows, newRows [][]string, keyCol int, styles DiffStyles) {
+	oldIndex := make(map[string]int, len(oldRows))
+	for i, row := range oldRows {
+		if _, ok := oldIndex[diffKey(row, keyCol)]; !ok {
+			oldIndex[diffKey(row, keyCol)] = i
+		}
+	}
+	newKeys := make(map[string]bool, len(newRows))
+	for _, row := range newRows {
+		newKeys[diffKey(row, keyCol)] = true
+	}
+
+	w.endRow()
+	removed := 0
+	writeRemoved := func(end int) {
+		for ; removed < end; removed++ {
+			if row := oldRows[removed]; !newKeys[diffKey(row, keyCol)] {
+				w.writeDiffRow("-", row, styles.Removed)
+			}
+		}
+	}
+
+	for _, row := range newRows {
+		i, ok := oldIndex[diffKey(row, keyCol)]
+		if !ok {
+			w.writeDiffRow("+", row, styles.Added)
+			continue
+		}
+
+		writeRemoved(i)
+		changed, cells := diffCells(oldRows[i], row)
+		if changed {
+			w.writeDiffRow("~", cells, styles.Changed)
+		} else {
+			w.writeDiffRow(" ", row, Style{})
+		}
+	}
+	writeRemoved(len(oldRows))
+}
+
+// writeDiffRow writes a row of cells preceded by a marker cell and
+// emphasizes it with style.
+func (w *Writer) writeDiffRow(marker string, cells []string, style Style) {
+	w.writeRecord(append([]string{marker}, cells...))
+	w.lines[len(w.lines)-2].style = style
+}
+
+// diffKey returns the text of a row's cell in column keyCol.
+func diffKey(row []string, keyCol int) string {
+	if keyCol < len(row) {
+		return row[keyCol]
+	}
+	return ""
+}
+
+// diffCells compares the cells of two versions of a row. It returns whether
+// any cell changed, along with the new cells, in which changed cells contain
+// both the old and new text.
+func diffCells(oldRow, newRow []string) (bool, []string) {
+	changed := false
+	cells := make([]string, max(len(oldRow), len(newRow)))
+	for j := range cells {
+		o, n := diffKey(oldRow, j), diffKey(newRow, j)
+		if o != n {
+			n = o + " → " + n
+			changed = true
+		}
+		cells[j] = n
+	}
+	return changed, cells
+}
@@ -0,0 +1,45 @@
+package tabwriter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteDiff(t *testing.T) {
+	oldRows := [][]string{
+		{"api", "3", "v1.2"},
+		{"cache", "1", "v7"},
+		{"web", "2", "v2.0"},
+		{"worker", "4", "v1.0"},
+	}
+	newRows := [][]string{
+		{"api", "3", "v1.3"},
+		{"web", "2", "v2.0"},
+		{"queue", "1", "v3"},
+		{"worker", "4", "v1.0"},
+	}
+
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.WriteDiff(oldRows, newRows, 0, DiffStyles{})
+	w.Flush()
+
+	want := "~ api    3 v1.2 → v1.3\n" +
+		"- cache  1 v7\n" +
+		"  web    2 v2.0\n" +
+		"+ queue  1 v3\n" +
+		"  worker 4 v1.0\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	w.WriteDiff(oldRows[1:2], newRows[2:3], 0, DiffColors)
+	w.Flush()
+
+	want = "\x1b[32m+\x1b[39m \x1b[32mqueue\x1b[39m \x1b[32m1\x1b[39m \x1b[32mv3\x1b[39m\n" +
+		"\x1b[31m-\x1b[39m \x1b[31mcache\x1b[39m \x1b[31m1\x1b[39m \x1b[31mv7\x1b[39m\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
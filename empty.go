@@ -0,0 +1,82 @@
+package tabwriter
+
+// An EmptyTablePolicy determines what a flush outputs for a table that has
+// a header but no other rows.
+type EmptyTablePolicy int
+
+const (
+	// EmptyTableHeader outputs the table's header row alone, if any. It is
+	// the default policy.
+	EmptyTableHeader EmptyTablePolicy = iota
+
+	// EmptyTableOmit outputs nothing in place of the table, neither its
+	// header nor its title. Raw text is still output.
+	EmptyTableOmit
+
+	// EmptyTableText outputs the table's header row, if any, followed by a
+	// row holding the text set by SetEmptyTableText, such as "no results".
+	EmptyTableText
+)
+
+// SetEmptyTablePolicy sets what a flush outputs for a table that has a
+// header, either the first row when UseFirstRowAsHeader is enabled or the
+// column names given to SetHeader, but no other rows. The column names given
+// to SetHeader are not output as a row, so a table with only those has no
+// header row to output, and the policy only applies to it if something, such
+// as raw text, was written since the last flush; flushing again after a
+// table outputs nothing. The policy only applies to the text, border and
+// markdown output formats.
+func (w *Writer) SetEmptyTablePolicy(policy EmptyTablePolicy) {
+	w.emptyPolicy = policy
+}
+
+// SetEmptyTableText sets the text output in place of the rows of a table
+// that has a header but no other rows, and selects the EmptyTableText
+// policy. An empty text restores the EmptyTableHeader policy.
+func (w *Writer) SetEmptyTableText(text string) {
+	w.emptyText = text
+	w.emptyPolicy = EmptyTableText
+	if text == "" {
+		w.emptyPolicy = EmptyTableHeader
+	}
+}
+
+// emptyLines applies the empty table policy to the buffered lines if they
+// contain a header but no other rows.
+func (w *Writer) emptyLines() {
+	switch {
+	case w.emptyPolicy == EmptyTableHeader:
+		return
+	case w.flushFormat != FormatText && !w.grid():
+		return
+	}
+
+	h := w.headerIndex()
+	if h < 0 && len(w.headerNames) == 0 {
+		return
+	}
+	for i, l := range w.lines {
+		if i != h && l.raw.size == 0 && l.groups == nil {
+			return
+		}
+	}
+	if h < 0 && w.buf.Len() == 0 {
+		// Nothing was written since the last flush.
+		return
+	}
+
+	switch w.emptyPolicy {
+	case EmptyTableOmit:
+		lines := w.lines[:0]
+		for _, l := range w.lines {
+			if l.raw.size > 0 {
+				lines = append(lines, l)
+			}
+		}
+		w.lines = lines
+		w.tracef("flush: omitted empty table")
+	case EmptyTableText:
+		w.lines = append(w.lines, w.newLine([]string{w.emptyText}))
+		w.tracef("flush: output %q for empty table", w.emptyText)
+	}
+}
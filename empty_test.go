@@ -0,0 +1,57 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestEmptyTable(t *testing.T) {
+	tests := []struct {
+		policy EmptyTablePolicy
+		rows   string
+		want   string
+	}{
+		{EmptyTableHeader, "", "NAME SIZE\n"},
+		{EmptyTableOmit, "", ""},
+		{EmptyTableText, "", "NAME SIZE\nno results\n"},
+		{EmptyTableText, "a.go\t12\n", "NAME SIZE\na.go 12\n"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.UseFirstRowAsHeader(true)
+		w.SetEmptyTableText("no results")
+		w.SetEmptyTablePolicy(test.policy)
+		fmt.Fprint(w, "NAME\tSIZE\n")
+		fmt.Fprint(w, test.rows)
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("policy %d: got:\n%q\nwant:\n%q", test.policy, b.String(), test.want)
+		}
+	}
+}
+
+func TestEmptyTableFlushes(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetHeader("NAME", "SIZE")
+	w.SetEmptyTableText("no results")
+	fmt.Fprint(w, "a\t1\nb\t2\n\nc\t3\n")
+	w.Flush()
+	w.Flush()
+	w.WriteRaw([]byte("raw\n"))
+	w.Flush()
+
+	want := "a 1\n" +
+		"b 2\n" +
+		"\n" +
+		"c 3\n" +
+		"raw\n" +
+		"no results\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
@@ -0,0 +1,129 @@
+package tabwriter_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/beevik/tabwriter"
+)
+
+func ExampleWriter() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	fmt.Fprint(w, "NAME\tSIZE\tMODIFIED\n")
+	fmt.Fprint(w, "go.mod\t94\tyesterday\n")
+	fmt.Fprint(w, "tabwriter.go\t11947\ttoday\n")
+	w.Flush()
+
+	// Output:
+	// NAME         SIZE  MODIFIED
+	// go.mod       94    yesterday
+	// tabwriter.go 11947 today
+}
+
+func ExampleWriter_description() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	w.SetDescriptionFormat(4, 40)
+	fmt.Fprint(w, "-v\tverbose\rPrint the name of each file as it is processed.\n")
+	fmt.Fprint(w, "-o file\toutput\rWrite the output to file instead of stdout.\n")
+	w.Flush()
+
+	// Output:
+	// -v       verbose
+	//     Print the name of each file as it is
+	//     processed.
+	// -o file  output
+	//     Write the output to file instead of
+	//     stdout.
+}
+
+func ExampleWriter_SetColumnFormat() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, tabwriter.AlignRight)
+	fmt.Fprint(w, "NAME\tSIZE\tMODIFIED\n")
+	fmt.Fprint(w, "go.mod\t94\tyesterday\n")
+	fmt.Fprint(w, "tabwriter.go\t11947\ttoday\n")
+	w.Flush()
+
+	// Output:
+	// NAME          SIZE MODIFIED
+	// go.mod          94 yesterday
+	// tabwriter.go 11947 today
+}
+
+func ExampleWriter_SetOutputFormat_border() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	w.SetOutputFormat(tabwriter.FormatBorder)
+	fmt.Fprint(w, "NAME\tSIZE\n")
+	fmt.Fprint(w, "go.mod\t94\n")
+	fmt.Fprint(w, "tabwriter.go\t11947\n")
+	w.Flush()
+
+	// Output:
+	// ┌──────────────┬───────┐
+	// │ NAME         │ SIZE  │
+	// │ go.mod       │ 94    │
+	// │ tabwriter.go │ 11947 │
+	// └──────────────┴───────┘
+}
+
+func ExampleWriter_SetOutputFormat_markdown() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	w.SetOutputFormat(tabwriter.FormatMarkdown)
+	fmt.Fprint(w, "NAME\tSIZE\n")
+	fmt.Fprint(w, "go.mod\t94\n")
+	fmt.Fprint(w, "tabwriter.go\t11947\n")
+	w.Flush()
+
+	// Output:
+	// | NAME         | SIZE  |
+	// |--------------|-------|
+	// | go.mod       | 94    |
+	// | tabwriter.go | 11947 |
+}
+
+func ExampleWriter_AutoAlign() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	w.AutoAlign(true)
+	fmt.Fprint(w, "NAME\tSIZE\tOWNER\n")
+	fmt.Fprint(w, "go.mod\t94\troot\n")
+	fmt.Fprint(w, "tabwriter.go\t11947\tbeevik\n")
+	w.Flush()
+
+	// Output:
+	// NAME          SIZE OWNER
+	// go.mod          94 root
+	// tabwriter.go 11947 beevik
+}
+
+func ExampleWriter_SortKeys() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	w.UseFirstRowAsHeader(true)
+	w.SortKeys([]tabwriter.SortKey{{Col: 1, Desc: true, Numeric: true}})
+	fmt.Fprint(w, "NAME\tSIZE\n")
+	fmt.Fprint(w, "go.mod\t94\n")
+	fmt.Fprint(w, "tabwriter.go\t11947\n")
+	fmt.Fprint(w, "layout.go\t8012\n")
+	w.Flush()
+
+	// Output:
+	// NAME         SIZE
+	// tabwriter.go 11947
+	// layout.go    8012
+	// go.mod       94
+}
+
+func ExampleWriter_GroupBy() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+	w.UseFirstRowAsHeader(true)
+	w.GroupBy(0, tabwriter.Count(1), tabwriter.Sum(2))
+	fmt.Fprint(w, "TEAM\tBUILDS\tMINUTES\n")
+	fmt.Fprint(w, "red\tx\t12\n")
+	fmt.Fprint(w, "blue\ty\t5\n")
+	fmt.Fprint(w, "red\tz\t30\n")
+	w.Flush()
+
+	// Output:
+	// TEAM BUILDS MINUTES
+	// red  2      42
+	// blue 1      5
+}
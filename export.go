@@ -0,0 +1,339 @@
+package tabwriter
+
+import (
+	"encoding/json"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Meta holds hidden metadata attached to a cell. Text output formats ignore
+// it, while the export formats include it: FormatHTML outputs each entry as
+// an attribute of the cell's element (for example a "title" tooltip),
+// omitting entries whose keys are not valid attribute names, and FormatJSON
+// outputs the entries as extra fields of the cell's record.
+type Meta map[string]string
+
+// AddRowWithMeta writes a row of cells like WriteRow, attaching meta[j] to
+// cell j. Cells without metadata may be given a nil Meta, and meta may be
+// shorter than cells. A partially written row is terminated before the new
+// row.
+func (w *Writer) AddRowWithMeta(cells []string, meta []Meta) {
+	w.endRow()
+	w.lines[len(w.lines)-1].meta = append([]Meta(nil), meta...)
+	w.WriteRow(cells...)
+}
+
+// isAttrName returns true if name is a valid HTML attribute name: it is not
+// empty and contains no white space, control characters, quotes, '>', '/'
+// or '='.
+func isAttrName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`"'>/=`, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// cellMeta returns the metadata attached to cell j of line l.
+func cellMeta(l line, j int) Meta {
+	if j < len(l.meta) {
+		return l.meta[j]
+	}
+	return nil
+}
+
+// writeHTML outputs the buffered lines as an HTML table. The first row and
+// any group header rows preceding it form the table's header. Raw text and
+// descriptions are omitted.
+func (w *Writer) writeHTML() {
+	w.linebuf.WriteString("<table>\n<thead>\n")
+	header := true
+	for _, l := range w.lines {
+		switch {
+		case l.raw.size > 0:
+			continue
+
+		case l.groups != nil:
+			w.linebuf.WriteString("<tr>")
+			for _, g := range l.groups {
+				w.linebuf.WriteString("<th")
+				if g.Span > 1 {
+					w.linebuf.WriteString(` colspan="` + strconv.Itoa(g.Span) + `"`)
+				}
+				w.linebuf.WriteString(">" + html.EscapeString(g.Title) + "</th>")
+			}
+			w.linebuf.WriteString("</tr>\n")
+			continue
+		}
+
+		tag := "td"
+		if header {
+			tag = "th"
+		}
+		w.linebuf.WriteString("<tr>")
+		for j, c := range l.cells {
+			w.linebuf.WriteString("<" + tag)
+			switch flags := w.getFormat(j).flags; {
+			case flags&AlignCenter != 0:
+				w.linebuf.WriteString(` style="text-align:center"`)
+			case flags&AlignRight != 0:
+				w.linebuf.WriteString(` style="text-align:right"`)
+			}
+			meta := cellMeta(l, j)
+			for _, k := range sortedKeys(meta) {
+				if !isAttrName(k) {
+					continue
+				}
+				w.linebuf.WriteString(" " + k + `="` + html.EscapeString(meta[k]) + `"`)
+			}
+			w.linebuf.WriteString(">" + html.EscapeString(unanchor(w.cellText(c))) + "</" + tag + ">")
+		}
+		w.linebuf.WriteString("</tr>\n")
+
+		if header {
+			w.linebuf.WriteString("</thead>\n<tbody>\n")
+			header = false
+		}
+	}
+	if header {
+		w.linebuf.WriteString("</thead>\n<tbody>\n")
+	}
+	w.linebuf.WriteString("</tbody>\n</table>\n")
+	w.emitLine(false)
+}
+
+// writeJSON outputs the buffered lines as a JSON array containing a record
+// for each row. The first row supplies the field names of the records; cells
+// in columns without a name use the column's number. A cell's metadata is
+// output in the record's "_meta" field, keyed by the cell's field name. Raw
+// text, descriptions and group headers are omitted. Records of rows missing
+// named columns have null fields for them if SetMissingAsNull is enabled,
+// while the row's explicitly empty cells, including a trailing one, are
+// empty strings.
+func (w *Writer) writeJSON() {
+	var names []string
+	w.linebuf.WriteString("[")
+	n := 0
+	for _, l := range w.lines {
+		if l.raw.size > 0 || l.groups != nil {
+			continue
+		}
+		if names == nil {
+			names = make([]string, 0, len(l.cells))
+			for _, c := range l.cells {
+				names = append(names, string(w.cellText(c)))
+			}
+			continue
+		}
+
+		if n > 0 {
+			w.linebuf.WriteString(",")
+		}
+		w.linebuf.WriteString("\n  {")
+		meta := make(map[string]Meta)
+		ncols, written := len(l.cells), w.writtenCells(l)
+		if w.missingNull {
+			ncols = max(written, len(names))
+		}
+		for j := 0; j < ncols; j++ {
+			name := strconv.Itoa(j)
+			if j < len(names) {
+				name = names[j]
+			}
+			if j > 0 {
+				w.linebuf.WriteString(", ")
+			}
+			switch {
+			case j >= written:
+				w.writeJSONField(name, nil)
+				continue
+			case j >= len(l.cells):
+				w.writeJSONField(name, "")
+				continue
+			}
+			w.writeJSONField(name, unanchor(w.cellText(l.cells[j])))
+			if m := cellMeta(l, j); len(m) > 0 {
+				meta[name] = m
+			}
+		}
+		if len(meta) > 0 {
+			w.linebuf.WriteString(", ")
+			w.writeJSONField("_meta", meta)
+		}
+		w.linebuf.WriteString("}")
+		n++
+	}
+	if n > 0 {
+		w.linebuf.WriteString("\n")
+	}
+	w.linebuf.WriteString("]\n")
+	w.emitLine(false)
+}
+
+// SetMissingAsNull sets whether exported records distinguish the columns
+// missing from short rows from cells that are explicitly empty, including an
+// empty cell ending a row, such as the last cell of "a\t\n" or of
+// WriteRow("a", ""). When enabled, FormatJSON outputs a null field for each
+// named column a row has no cell in, while empty cells are empty strings.
+// TSV has no null value, so FormatTSV instead ends a row whose last cell is
+// empty with a tab, giving it one more field than a row missing the column.
+// When disabled, the fields of missing columns and of trailing empty cells
+// are omitted.
+func (w *Writer) SetMissingAsNull(enabled bool) {
+	w.missingNull = enabled
+}
+
+// writtenCells returns the number of cells written in line l, counting an
+// explicitly empty cell ending the line when SetMissingAsNull is enabled.
+func (w *Writer) writtenCells(l line) int {
+	if w.missingNull && l.emptyEnd {
+		return len(l.cells) + 1
+	}
+	return len(l.cells)
+}
+
+// writeJSONField outputs a JSON object member.
+func (w *Writer) writeJSONField(name string, value interface{}) {
+	key, _ := json.Marshal(name)
+	val, _ := json.Marshal(value)
+	w.linebuf.Write(key)
+	w.linebuf.WriteString(": ")
+	w.linebuf.Write(val)
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m Meta) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SetHeader registers the names of the columns, which are used by output
+// formats that label cells with their column's name, such as
+// FormatAccessible. Columns it does not name are named by the header row if
+// UseFirstRowAsHeader is enabled, and are otherwise called "column N",
+// counting from 1.
+func (w *Writer) SetHeader(names ...string) {
+	w.headerNames = append([]string(nil), names...)
+	w.resolveColumnNames(w.headerNames)
+}
+
+// columnName returns the name of column col, given the cells of the header
+// row, if any.
+func (w *Writer) columnName(col int, header []cell) string {
+	switch {
+	case col < len(w.headerNames) && w.headerNames[col] != "":
+		return w.headerNames[col]
+	case col < len(header) && header[col].size > 0:
+		return string(w.cellText(header[col]))
+	}
+	return "column " + strconv.Itoa(col+1)
+}
+
+// writeAccessible outputs each buffered row as a line of "name: value" pairs
+// in column order. Every named column is included, even if the row has no
+// cell in it. Raw text is output unchanged, descriptions follow their
+// rows on a line of their own, and group headers are omitted. The header row
+// only supplies names and is not output itself.
+func (w *Writer) writeAccessible() {
+	var header []cell
+	if h := w.headerIndex(); h >= 0 {
+		header = w.lines[h].cells
+	}
+	for _, l := range w.lines {
+		switch {
+		case l.raw.size > 0:
+			w.linebuf.Write(w.cellText(l.raw))
+			w.emitLine(false)
+			continue
+		case l.groups != nil, l.header:
+			continue
+		}
+
+		ncols := max(max(len(l.cells), len(w.headerNames)), len(header))
+		for j := 0; j < ncols; j++ {
+			if j > 0 {
+				w.linebuf.WriteString("; ")
+			}
+			w.linebuf.WriteString(w.columnName(j, header) + ":")
+			if j < len(l.cells) && l.cells[j].size > 0 {
+				w.linebuf.WriteString(" ")
+				w.linebuf.WriteString(strings.ReplaceAll(unanchor(w.cellText(l.cells[j])), "\n", " "))
+			}
+		}
+		if l.annotation != "" {
+			w.linebuf.WriteString(" (" + l.annotation + ")")
+		}
+		w.linebuf.Write(newline)
+		if l.description.size > 0 {
+			w.linebuf.WriteString("description: ")
+			w.linebuf.Write(w.cellText(l.description))
+			w.linebuf.Write(newline)
+		}
+		w.emitLine(false)
+	}
+}
+
+// writeTSV outputs each buffered row as its cells separated by tabs. Raw
+// text, descriptions, annotations and group headers are omitted. A trailing
+// empty cell is kept if SetMissingAsNull is enabled.
+func (w *Writer) writeTSV() {
+	if w.schemaComment {
+		w.writeSchemaComment()
+	}
+	for _, l := range w.lines {
+		if l.raw.size > 0 || l.groups != nil {
+			continue
+		}
+		for j, c := range l.cells {
+			if j > 0 {
+				w.linebuf.WriteByte('\t')
+			}
+			// The lines of multi-line cells are joined by spaces.
+			w.linebuf.WriteString(strings.ReplaceAll(unanchor(w.cellText(c)), "\n", " "))
+		}
+		if w.writtenCells(l) > len(l.cells) {
+			w.linebuf.WriteByte('\t')
+		}
+		w.linebuf.Write(newline)
+		w.emitLine(false)
+	}
+}
+
+// SetSchemaComment sets whether FormatTSV output begins with a comment line
+// describing the name and type of each column, such as
+//
+//	# name:text size:integer ratio:float
+//
+// so that programs parsing the output don't have to guess. Columns are named
+// like those of FormatAccessible, with spaces replaced by underscores, and
+// their types are the names of the ColumnTypes reported by InferSchema.
+func (w *Writer) SetSchemaComment(enabled bool) {
+	w.schemaComment = enabled
+}
+
+// writeSchemaComment outputs the comment line describing the buffered
+// columns.
+func (w *Writer) writeSchemaComment() {
+	var header []cell
+	if h := w.headerIndex(); h >= 0 {
+		header = w.lines[h].cells
+	}
+	w.linebuf.WriteString("#")
+	for j, s := range w.InferSchema() {
+		name := strings.Join(strings.Fields(w.columnName(j, header)), "_")
+		w.linebuf.WriteString(" " + name + ":" + s.Type.String())
+	}
+	w.linebuf.Write(newline)
+	w.emitLine(false)
+}
@@ -0,0 +1,173 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		format OutputFormat
+		want   string
+	}{
+		{FormatText, "" +
+			"name  size\n" +
+			"a.go  12K\n" +
+			"b<>.c 4K\n"},
+		{FormatHTML, "" +
+			"<table>\n" +
+			"<thead>\n" +
+			"<tr><th>name</th><th style=\"text-align:right\">size</th></tr>\n" +
+			"</thead>\n" +
+			"<tbody>\n" +
+			"<tr><td>a.go</td><td style=\"text-align:right\" title=\"12288 bytes\">12K</td></tr>\n" +
+			"<tr><td>b&lt;&gt;.c</td><td style=\"text-align:right\">4K</td></tr>\n" +
+			"</tbody>\n" +
+			"</table>\n"},
+		{FormatJSON, "" +
+			"[\n" +
+			"  {\"name\": \"a.go\", \"size\": \"12K\", \"_meta\": {\"size\":{\"title\":\"12288 bytes\"}}},\n" +
+			"  {\"name\": \"b\\u003c\\u003e.c\", \"size\": \"4K\"}\n" +
+			"]\n"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.SetColumnFormat(1, 0, 1, AlignRight)
+		w.SetOutputFormat(test.format)
+		w.AddRowWithMeta([]string{"name", "size"}, nil)
+		w.AddRowWithMeta([]string{"a.go", "12K"}, []Meta{nil, {"title": "12288 bytes"}})
+		w.AddRowWithMeta([]string{"b<>.c", "4K"}, nil)
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("format %d: got:\n%s\nwant:\n%s", test.format, b.String(), test.want)
+		}
+	}
+}
+
+func TestAccessible(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetOutputFormat(FormatAccessible)
+	w.SetHeader("name", "size")
+	w.AddRowWithMeta([]string{"a.go", "12K", "new"}, nil)
+	w.AddRowWithMeta([]string{"b.go", ""}, nil)
+	w.Flush()
+
+	want := "name: a.go; size: 12K; column 3: new\n" +
+		"name: b.go; size:\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSetMissingAsNull(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetOutputFormat(FormatJSON)
+	w.SetMissingAsNull(true)
+	fmt.Fprint(w, "name\towner\tsize\n")
+	fmt.Fprint(w, "a.go\t\t12K\n")
+	fmt.Fprint(w, "b.go\n")
+	w.Flush()
+
+	want := "[\n" +
+		"  {\"name\": \"a.go\", \"owner\": \"\", \"size\": \"12K\"},\n" +
+		"  {\"name\": \"b.go\", \"owner\": null, \"size\": null}\n" +
+		"]\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSetMissingAsNullExplicit(t *testing.T) {
+	tests := []struct {
+		format OutputFormat
+		want   string
+	}{
+		{FormatJSON, "[\n" +
+			"  {\"name\": \"a\", \"note\": \"\"},\n" +
+			"  {\"name\": \"b\", \"note\": null},\n" +
+			"  {\"name\": \"c\", \"note\": \"\"},\n" +
+			"  {\"name\": \"d\", \"note\": null},\n" +
+			"  {\"name\": \"\", \"note\": null}\n" +
+			"]\n"},
+		{FormatTSV, "name\tnote\n" +
+			"a\t\n" +
+			"b\n" +
+			"c\t\n" +
+			"d\n" +
+			"\n"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.SetOutputFormat(test.format)
+		w.SetMissingAsNull(true)
+		w.WriteRow("name", "note")
+		w.WriteRow("a", "")
+		w.WriteRow("b")
+		fmt.Fprint(w, "c\t\n")
+		fmt.Fprint(w, "d\n")
+		w.WriteRow("")
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("format %v: got:\n%q\nwant:\n%q", test.format, b.String(), test.want)
+		}
+	}
+}
+
+func TestSchemaComment(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetOutputFormat(FormatTSV)
+	w.SetSchemaComment(true)
+	w.UseFirstRowAsHeader(true)
+	fmt.Fprint(w, "file name\tsize\tratio\n")
+	fmt.Fprint(w, "a.go\t120\t0.5\n")
+	fmt.Fprint(w, "b.go\t4,096\t12%\n")
+	w.Flush()
+
+	want := "# file_name:text size:integer ratio:float\n" +
+		"file name\tsize\tratio\n" +
+		"a.go\t120\t0.5\n" +
+		"b.go\t4,096\t12%\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestAddRowWithMeta(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetOutputFormat(FormatHTML)
+	w.AddRowWithMeta([]string{"name", "size"}, nil)
+	w.AddRowWithMeta([]string{"a\tb", "12K"}, []Meta{nil, {"title": "12288 bytes", "x\" onclick": "y", "": "z"}})
+	w.AddRowWithMeta([]string{"c\nd", "4K"}, []Meta{{"title": "two lines"}})
+	w.AddRowWithMeta(nil, nil)
+	w.AddRowWithMeta([]string{""}, nil)
+	if b.Len() != 0 {
+		t.Errorf("got output before Flush: %q", b.String())
+	}
+	w.Flush()
+
+	want := "<table>\n" +
+		"<thead>\n" +
+		"<tr><th>name</th><th>size</th></tr>\n" +
+		"</thead>\n" +
+		"<tbody>\n" +
+		"<tr><td>a b</td><td title=\"12288 bytes\">12K</td></tr>\n" +
+		"<tr><td title=\"two lines\">c\nd</td><td>4K</td></tr>\n" +
+		"<tr></tr>\n" +
+		"<tr><td></td></tr>\n" +
+		"</tbody>\n" +
+		"</table>\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
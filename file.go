@@ -0,0 +1,253 @@
+package tabwriter
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// FormatFile formats the tab-delimited text read from src and writes it to
+// dst using the settings in cfg. The output is identical to that of a Writer
+// with the same settings that is flushed once all of src has been written.
+//
+// If src implements io.Seeker, it is read twice: once to measure the
+// columns and once to output them. Only the column widths are kept in
+// memory, so arbitrarily large files can be formatted. Otherwise, all of
+// src's text is buffered in memory before it is output.
+func FormatFile(dst io.Writer, src io.Reader, cfg Config) error {
+	w := NewWriterConfig(dst, cfg)
+
+	s, ok := src.(io.Seeker)
+	if !ok {
+		if _, err := io.Copy(w, src); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+
+	start, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	// Aligning decimals requires the width of each column's widest
+	// fraction, which takes a pass of its own.
+	f := &fileFormatter{w: w}
+	passes := []func(l *line){f.measureLine, f.outputLine}
+	if w.alignsDecimals() {
+		passes = append([]func(l *line){f.measureFractions}, passes...)
+	}
+	for i, pass := range passes {
+		if i > 0 {
+			if _, err := s.Seek(start, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		f.measuring = i < len(passes)-1
+		if err := f.scan(src, pass); err != nil {
+			return err
+		}
+	}
+	return w.err
+}
+
+// fileFormatter formats a file one line at a time using column widths
+// measured during a preliminary pass over the file.
+type fileFormatter struct {
+	w         *Writer
+	blocks    [][]int       // widths of each column's blocks, in order of appearance
+	open      []block       // each column's current block
+	first     bool          // the next line is the first of its section
+	measuring bool          // the formatter is measuring rather than outputting
+	fractions []map[int]int // widths of each column's widest fraction, by section
+	section   int           // index of the current section
+}
+
+// block tracks a column's current block of cells.
+type block struct {
+	open  bool // a block is in progress
+	width int  // width of the block's cells
+	lines int  // number of lines in the block
+	first bool // the block began on the first line of its section
+	empty bool // all of the block's cells are empty
+}
+
+// scan reads the lines of the file from r, parses each with the formatter's
+// Writer and calls fn for each parsed line. Sections of the file, which are
+// separated by empty lines or form feeds, are sized independently.
+func (f *fileFormatter) scan(r io.Reader, fn func(l *line)) error {
+	f.first = true
+	f.open = f.open[:0]
+	f.section = 0
+	br := bufio.NewReader(r)
+	for {
+		text, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		text = bytes.TrimSuffix(text, newline)
+
+		// Form feeds end both a line and a section.
+		records := bytes.Split(text, []byte{'\f'})
+		for i, rec := range records {
+			last := i == len(records)-1
+			if last && len(rec) == 0 && err == io.EOF && !f.pending() {
+				break
+			}
+
+			f.w.Write(rec)
+			if f.w.endChar != 0 && !(last && err == io.EOF) {
+				// The line break or form feed is escaped: it is part of
+				// the cell's text, and the line continues.
+				if last {
+					f.w.Write(newline)
+				} else {
+					f.w.Write([]byte{'\f'})
+				}
+				continue
+			}
+			if last && err == io.EOF {
+				// Finish the final line the same way Flush does.
+				if f.w.cell.size > 0 {
+					f.w.addCell(f.w, true)
+				}
+			} else {
+				f.w.Write(newline)
+			}
+
+			// Lines without cells, which a Writer would flush at, begin a
+			// new section. Like Flush, drop them if they end the section.
+			l := &f.w.lines[0]
+			if len(l.cells) == 0 {
+				f.endSection()
+			}
+			if len(l.cells) > 0 || (last && err == nil) {
+				fn(l)
+				f.first = false
+			}
+			if !last {
+				f.endSection()
+			}
+			f.w.reset()
+		}
+
+		if err == io.EOF {
+			f.endSection()
+			return nil
+		}
+	}
+}
+
+// pending returns true if the line being parsed has any text.
+func (f *fileFormatter) pending() bool {
+	return f.w.cell.size > 0 || len(f.w.lines[0].cells) > 0
+}
+
+// endSection closes all columns' blocks at the end of a section.
+func (f *fileFormatter) endSection() {
+	f.closeBlocks(0)
+	f.first = true
+	f.section++
+	f.w.header = nil
+}
+
+// closeBlocks closes the blocks of column col and all columns following it.
+func (f *fileFormatter) closeBlocks(col int) {
+	for ; col < len(f.open); col++ {
+		if b := &f.open[col]; b.open {
+			switch {
+			case b.empty && f.w.format.flags&DiscardEmptyColumns != 0 && !f.w.grid():
+				b.width = 0
+			case f.w.padchar == '\t' && (b.lines > 1 || !b.first):
+				b.width = f.w.tabify(b.width)
+			}
+			if f.measuring {
+				f.blocks[col] = append(f.blocks[col], b.width)
+			}
+			b.open = false
+		}
+	}
+}
+
+// measureFractions accumulates the widths of the fractions of a line's
+// numbers in columns aligned with AlignDecimal or AlignNumeric.
+func (f *fileFormatter) measureFractions(l *line) {
+	for len(f.fractions) <= f.section {
+		f.fractions = append(f.fractions, make(map[int]int))
+	}
+	fractions := f.fractions[f.section]
+	f.w.forEachDecimal(func(col int, c *cell, fraction int) {
+		fractions[col] = max(fractions[col], fraction)
+	})
+}
+
+// padDecimals pads the numbers of the line being formatted so that their
+// fractions line up with those of the rest of the section.
+func (f *fileFormatter) padDecimals() {
+	if f.section < len(f.fractions) {
+		f.w.padDecimals(f.fractions[f.section])
+	}
+}
+
+// measureLine accumulates the widths of a line's cells into the blocks of
+// their columns.
+func (f *fileFormatter) measureLine(l *line) {
+	f.padDecimals()
+	last := len(l.cells) - 1
+	f.closeBlocks(max(last, 0))
+	for col := 0; col < last; col++ {
+		if col == len(f.open) {
+			f.open = append(f.open, block{})
+			f.blocks = append(f.blocks, nil)
+		}
+
+		c, b := &l.cells[col], &f.open[col]
+		if !b.open {
+			*b = block{open: true, first: f.first, empty: true}
+		}
+		textwidth := f.w.textWidth(c, col)
+		b.width = max(b.width, f.w.cellWidth(c, col, textwidth))
+		b.empty = b.empty && textwidth == 0
+		b.lines++
+	}
+}
+
+// outputLine assigns the measured widths to a line's cells and outputs it.
+func (f *fileFormatter) outputLine(l *line) {
+	f.padDecimals()
+	last := len(l.cells) - 1
+	for col := range l.cells {
+		if col == len(f.open) {
+			f.open = append(f.open, block{})
+		}
+
+		c, b := &l.cells[col], &f.open[col]
+		if col < last {
+			if !b.open {
+				*b = block{open: true, width: f.blocks[col][0]}
+				f.blocks[col] = f.blocks[col][1:]
+			}
+			c.maxwidth = b.width
+			continue
+		}
+
+		// The line's last cell is at least as wide as the block above it.
+		c.maxwidth = f.w.cellWidth(c, col, f.w.textWidth(c, col))
+		if b.open {
+			c.maxwidth = max(c.maxwidth, b.width)
+		}
+		if f.w.padchar == '\t' && !f.first {
+			c.maxwidth = f.w.tabify(c.maxwidth)
+		}
+	}
+	for col := max(last, 0); col < len(f.open); col++ {
+		f.open[col].open = false
+	}
+
+	f.w.writeLine(*l)
+	f.w.emitLine(true)
+	if l.description.size > 0 {
+		f.w.writeDescription(f.w.cellText(l.description))
+		f.w.emitLine(false)
+	}
+}
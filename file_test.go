@@ -0,0 +1,106 @@
+package tabwriter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFormatFile(t *testing.T) {
+	inputs := []string{
+		"",
+		"a\tb\tc\n",
+		"a\tbbb\tc\naaa\tb\tc\n",
+		"a\tb\tc\td\t.\n1234\t123456789\t1\t.\nAAA\tB\tC\t\n123\t12345\t1234567\t123456\t200\t18\n",
+		"x\ty\n\nlonger\tz\n",
+		"x\ty\fshort\tz\nlonger\tzz\n",
+		"x\ty\f\nshort\tz\n\n\n",
+		"\rdesc only\nx\ty\n",
+		"--flags\tvalue\rFormatting flags\rand more\n--x\tv\n",
+		"no\ttrailing\tnewline",
+		"one\ttrailing\ttab\t\ntwo\t\t\n",
+		"a\t\xffx\ny\xff\tb\ncc\td\n",
+		"a\t\xffx\fy\xff\tb\ncc\td\n",
+		"a\t\xffx\ny",
+		"n\t1.5\tx\nnn\t12.25\tx\n\nn\t-3\tx\n",
+		"n\t1.5 kB\tx\nnn\t12.25%\tx\nn\t-\tx\n",
+	}
+	configs := []Config{
+		Defaults(),
+		{MinWidth: 4, TabWidth: 8, Padding: 2, PadChar: '.', Flags: AlignRight, DescriptionIndent: 2, DescriptionWrap: 10},
+		{MinWidth: 0, TabWidth: 4, Padding: 1, PadChar: '\t', DescriptionIndent: 8, DescriptionWrap: 72},
+		{MinWidth: 0, TabWidth: 8, Padding: 1, PadChar: ' ', Flags: AlignDecimal},
+		{MinWidth: 0, TabWidth: 8, Padding: 1, PadChar: ' ', Flags: AlignNumeric},
+	}
+
+	for _, cfg := range configs {
+		for _, in := range inputs {
+			checkFormatFile(t, in, cfg)
+		}
+	}
+}
+
+// TestFormatFileFlags compares FormatFile with a Writer for every
+// combination of the formatting flags.
+func TestFormatFileFlags(t *testing.T) {
+	inputs := []string{
+		"\t\t\t\tbbx y",
+		"a\t\tb\n\t\tc\n",
+		"a\t\t\tb\nx\t\t\ty\n\n\tz\t\n",
+		"<b>a</b>\t&amp;\tc\n\xffx\ty\xff\t\t1.5\n",
+		"\tindented\tx\n\t\tdeeper\n",
+		"n\t1.5\t\tx\nnn\t12.25\t\tx\n",
+	}
+	flags := []uint{AlignRight, AlignCenter, AlignDecimal, DiscardEmptyColumns, Debug, TabIndent, FilterHTML, StripEscape}
+	for _, padchar := range []byte{' ', '.', '\t'} {
+		for set := 0; set < 1<<len(flags); set++ {
+			cfg := Config{MinWidth: 0, TabWidth: 8, Padding: 1, PadChar: padchar}
+			for i, flag := range flags {
+				if set&(1<<i) != 0 {
+					cfg.Flags |= flag
+				}
+			}
+			for _, in := range inputs {
+				checkFormatFile(t, in, cfg)
+			}
+		}
+	}
+}
+
+// checkFormatFile checks that FormatFile formats in like a Writer with the
+// same settings, both with and without a seekable source.
+func checkFormatFile(t *testing.T, in string, cfg Config) {
+	t.Helper()
+	var want bytes.Buffer
+	w := NewWriterConfig(&want, cfg)
+	io.WriteString(w, in)
+	w.Flush()
+
+	var seekable, streamed bytes.Buffer
+	if err := FormatFile(&seekable, strings.NewReader(in), cfg); err != nil {
+		t.Fatalf("FormatFile(%q): %v", in, err)
+	}
+	if err := FormatFile(&streamed, struct{ io.Reader }{strings.NewReader(in)}, cfg); err != nil {
+		t.Fatalf("FormatFile(%q): %v", in, err)
+	}
+
+	if seekable.String() != want.String() {
+		t.Errorf("FormatFile(%q) with seeker, flags %#x, padchar %q:\ngot:\n%q\nwant:\n%q", in, cfg.Flags, cfg.PadChar, seekable.String(), want.String())
+	}
+	if streamed.String() != want.String() {
+		t.Errorf("FormatFile(%q) without seeker, flags %#x, padchar %q:\ngot:\n%q\nwant:\n%q", in, cfg.Flags, cfg.PadChar, streamed.String(), want.String())
+	}
+}
+
+func TestFormatFileError(t *testing.T) {
+	failure := errors.New("disk full")
+	in := "a\tb\nc\td\n"
+	for _, src := range []io.Reader{strings.NewReader(in), struct{ io.Reader }{strings.NewReader(in)}} {
+		out := &failWriter{n: 1, err: failure}
+		if err := FormatFile(out, src, Defaults()); err != failure {
+			t.Errorf("got error %v, want %v", err, failure)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package tabwriter
+
+import "strconv"
+
+// AddFootnote registers a footnote for the current flush and returns its
+// marker, such as "[1]", to be written into the cell it annotates. Footnotes
+// are numbered in the order they are added, and adding the same text again
+// returns the existing marker. When the writer is flushed, the footnotes are
+// output after the table, each preceded by its marker and word-wrapped like
+// descriptions, and the numbering starts over. Export formats such as
+// FormatJSON omit footnotes.
+func (w *Writer) AddFootnote(text string) string {
+	n := indexOf(w.footnotes, text) + 1
+	if n == 0 {
+		w.footnotes = append(w.footnotes, text)
+		n = len(w.footnotes)
+	}
+	return footnoteMarker(n)
+}
+
+// footnoteMarker returns the marker of footnote n.
+func footnoteMarker(n int) string {
+	return "[" + strconv.Itoa(n) + "]"
+}
+
+// writeFootnotes outputs the footnotes of the current flush. Lines that wrap
+// are indented to align with the text following the marker.
+func (w *Writer) writeFootnotes() {
+	if len(w.footnotes) == 0 {
+		return
+	}
+
+	w.linebuf.Write(newline)
+	for i, text := range w.footnotes {
+		prefix := footnoteMarker(i+1) + " "
+		w.writeWrapped([]byte(text), prefix, w.measure([]byte(prefix)))
+	}
+	w.emitLine(false)
+}
@@ -0,0 +1,36 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestAddFootnote(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetDescriptionFormat(8, 30)
+	fmt.Fprintf(w, "-old%s\tstring\n", w.AddFootnote("Deprecated; use -new instead, which also accepts a list."))
+	fmt.Fprintf(w, "-new\tlist\n")
+	fmt.Fprintf(w, "-legacy%s\tbool\n", w.AddFootnote("Deprecated; use -new instead, which also accepts a list."))
+	fmt.Fprintf(w, "-x%s\tint\n", w.AddFootnote("Experimental."))
+	w.Flush()
+	fmt.Fprintf(w, "-y%s\tint\n", w.AddFootnote("Experimental."))
+	w.Flush()
+
+	want := "-old[1]    string\n" +
+		"-new       list\n" +
+		"-legacy[1] bool\n" +
+		"-x[2]      int\n" +
+		"\n" +
+		"[1] Deprecated; use -new\n" +
+		"    instead, which also\n" +
+		"    accepts a list.\n" +
+		"[2] Experimental.\n" +
+		"-y[1] int\n" +
+		"\n" +
+		"[1] Experimental.\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
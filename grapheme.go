@@ -0,0 +1,83 @@
+package tabwriter
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	zwj                = '\u200d' // zero-width joiner
+	emojiPresentation  = '\ufe0f' // variation selector requesting emoji
+	regionalIndicatorA = 0x1f1e6
+	regionalIndicatorZ = 0x1f1ff
+)
+
+// nextCluster returns the first rune of the grapheme cluster at the start of
+// text, the cluster's size in bytes and the number of columns it occupies.
+// Grapheme clusters are sequences of runes that terminals display as a
+// single glyph, such as a letter followed by combining accents, an emoji
+// with a skin tone modifier, a flag made of two regional indicators, or
+// several emoji joined by zero-width joiners. The segmentation approximates
+// the extended grapheme clusters of Unicode Standard Annex #29, which
+// suffices for measuring text.
+func nextCluster(text []byte) (rune, int, int) {
+	r, size := utf8.DecodeRune(text)
+	width := runeWidth(r)
+
+	// A pair of regional indicators forms a flag.
+	if isRegionalIndicator(r) {
+		if next, n := utf8.DecodeRune(text[size:]); isRegionalIndicator(next) {
+			return r, size + n, 2
+		}
+		return r, size, width
+	}
+
+	for size < len(text) {
+		next, n := utf8.DecodeRune(text[size:])
+		switch {
+		case next == zwj && pictographic(r):
+			// A joiner attaches the following pictograph to the cluster.
+			size += n
+			if after, m := utf8.DecodeRune(text[size:]); m > 0 && pictographic(after) {
+				size += m
+			}
+			continue
+		case next == emojiPresentation && width == 1:
+			// Symbols presented as emoji occupy two columns.
+			width = 2
+		case !extendsCluster(next):
+			return r, size, width
+		}
+		size += n
+	}
+	return r, size, width
+}
+
+// extendsCluster returns true if rune r belongs to the grapheme cluster of
+// the runes preceding it: combining marks, variation selectors, emoji
+// modifiers and tags, and zero-width joiners.
+func extendsCluster(r rune) bool {
+	switch {
+	case r < 0x300:
+		return false
+	case r == zwj:
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff: // emoji modifiers (skin tones)
+		return true
+	case r >= 0xe0020 && r <= 0xe007f: // tags of subdivision flags
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+// isRegionalIndicator returns true if r is one of the regional indicator
+// symbols that form flags in pairs.
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicatorA && r <= regionalIndicatorZ
+}
+
+// pictographic returns true if r is a pictograph that zero-width joiners
+// combine into emoji sequences.
+func pictographic(r rune) bool {
+	return r >= 0x2600 && r <= 0x27bf || r >= 0x1f000 && r <= 0x1faff
+}
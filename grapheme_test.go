@@ -0,0 +1,45 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestGraphemeWidth(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+	}{
+		{"cafe\u0301", 4},                     // combining acute accent
+		{"\U0001f44d\U0001f3fd", 2},           // thumbs up with skin tone
+		{"\U0001f1ef\U0001f1f5", 2},           // flag
+		{"\U0001f468\u200d\U0001f4bb", 2},     // technologist (ZWJ sequence)
+		{"\u2764\ufe0f", 2},                   // heart with emoji presentation
+		{"a\u200db", 2},                       // joiner between letters
+		{"\U0001f1ef\U0001f1f5\U0001f1fa", 3}, // flag and a lone indicator
+	}
+
+	for _, test := range tests {
+		if got := displayWidth([]byte(test.text)); got != test.width {
+			t.Errorf("displayWidth(%+q) = %d, want %d", test.text, got, test.width)
+		}
+	}
+}
+
+func TestGraphemeAlignment(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnMaxWidth(1, 4)
+	fmt.Fprint(w, "status\tteam\towner\n")
+	fmt.Fprint(w, "\U0001f44d\U0001f3fd\t\U0001f468\u200d\U0001f4bb\U0001f469\u200d\U0001f52c\U0001f1ef\U0001f1f5\tann\n")
+	fmt.Fprint(w, "ok\tdev\tbob\n")
+	w.Flush()
+
+	want := "status team owner\n" +
+		"\U0001f44d\U0001f3fd     \U0001f468\u200d\U0001f4bb…  ann\n" +
+		"ok     dev  bob\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
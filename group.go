@@ -0,0 +1,157 @@
+package tabwriter
+
+// A Group is a title spanning a run of adjacent columns in a group header
+// row.
+type Group struct {
+	Title string // text output above the spanned columns
+	Span  int    // number of columns spanned
+}
+
+// WriteGroupHeader writes a group header row, whose cells each span a run of
+// adjacent columns. The width of each group is derived at flush time from the
+// widths of the spanned columns in the row that follows it. Like raw text, a
+// group header row does not end the current alignment section. A partially
+// written row is terminated before the group header.
+func (w *Writer) WriteGroupHeader(groups ...Group) {
+	w.endRow()
+	if len(groups) == 0 {
+		return
+	}
+
+	l := &w.lines[len(w.lines)-1]
+	for _, g := range groups {
+		// Every group spans at least one column.
+		g.Span = max(g.Span, 1)
+		l.groups = append(l.groups, g)
+	}
+	w.addNewLine()
+}
+
+// nextTableLine returns the first line following line i that contains cells.
+func (w *Writer) nextTableLine(i int) line {
+	for _, l := range w.lines[i+1:] {
+		if l.raw.size == 0 && l.groups == nil && len(l.cells) > 0 {
+			return l
+		}
+	}
+	return line{}
+}
+
+// writeGroups outputs a group header row, spanning the columns of the line
+// that follows it.
+func (w *Writer) writeGroups(groups []Group, next line) {
+	col := 0
+	for i, g := range groups {
+		width := 0
+		for j := col; j < col+g.Span && j < len(next.cells); j++ {
+			width += next.cells[j].maxwidth
+		}
+
+		format := w.getFormat(col)
+		textwidth := w.measure([]byte(g.Title))
+		padding := max(width-textwidth, format.padding)
+		w.writeCell([]byte(g.Title), padding, format, i == len(groups)-1)
+		col += g.Span
+	}
+	w.linebuf.Write(newline)
+}
+
+// fitGroups widens the columns of a grid so that each group title fits in
+// the columns it spans, and returns the adjusted column widths.
+func (w *Writer) fitGroups(widths []int) []int {
+	for _, l := range w.lines {
+		col := 0
+		for _, g := range l.groups {
+			for len(widths) < col+g.Span {
+				widths = append(widths, 0)
+			}
+			width := w.measure([]byte(g.Title)) + w.getFormat(col).padding
+			if w.flushFormat == FormatMarkdown {
+				widths[col] = max(widths[col], width)
+			} else if extra := width - w.spanWidth(widths[col:col+g.Span]); extra > 0 {
+				widths[col+g.Span-1] += extra
+			}
+			col += g.Span
+		}
+	}
+	return widths
+}
+
+// spanWidth returns the width of the text area of a grid cell spanning
+// columns of the given widths, which includes the separators between them.
+func (w *Writer) spanWidth(widths []int) int {
+	gap := 1
+	if _, sep, _ := w.gridEdges(); sep != "" {
+		gap += w.measure([]byte(sep))
+	}
+
+	width := 0
+	for _, n := range widths {
+		width += n + gap
+	}
+	return max(width-gap, 0)
+}
+
+// groupWidths returns the widths of a grid's cells in a group header row.
+// Columns following the last group are output as cells of their own.
+func (w *Writer) groupWidths(groups []Group, widths []int) []int {
+	var spans []int
+	col := 0
+	for _, g := range groups {
+		end := min(col+g.Span, len(widths))
+		spans = append(spans, w.spanWidth(widths[min(col, end):end]))
+		col += g.Span
+	}
+	for ; col < len(widths); col++ {
+		spans = append(spans, widths[col])
+	}
+	return spans
+}
+
+// writeGridGroups outputs a group header row in a grid. Markdown tables
+// cannot span columns, so each title is output in the first column of its
+// group instead.
+func (w *Writer) writeGridGroups(groups []Group, widths []int) {
+	if w.flushFormat == FormatMarkdown {
+		cells := make([]cell, 0, len(widths))
+		for _, g := range groups {
+			for j := 0; j < g.Span; j++ {
+				var c cell
+				if j == 0 {
+					c = cell{start: w.buf.Len(), size: len(g.Title), width: w.measure([]byte(g.Title))}
+					w.buf.WriteString(g.Title)
+				}
+				cells = append(cells, c)
+			}
+		}
+		w.writeGridLine(line{cells: cells}, widths)
+		return
+	}
+
+	left, sep, right := w.gridEdges()
+	spans := w.groupWidths(groups, widths)
+	w.linebuf.WriteString(left)
+	col := 0
+	for i, width := range spans {
+		var title string
+		if i < len(groups) {
+			title = groups[i].Title
+		}
+		textwidth := w.measure([]byte(title))
+		if i > 0 {
+			w.linebuf.WriteString(sep)
+		}
+		if i > 0 || left != "" {
+			w.linebuf.Write(space)
+		}
+		term := i == len(spans)-1 && right == ""
+		w.writeCell([]byte(title), width-textwidth, w.getFormat(col), term)
+		if i < len(groups) {
+			col += groups[i].Span
+		} else {
+			col++
+		}
+	}
+	w.linebuf.WriteString(right)
+	w.linebuf.Write(newline)
+}
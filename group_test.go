@@ -0,0 +1,48 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestWriteGroupHeader(t *testing.T) {
+	tests := []struct {
+		format OutputFormat
+		want   string
+	}{
+		{FormatText, "" +
+			"         Requests\n" +
+			"endpoint ok   err p99\n" +
+			"/users   1204 3   41ms\n" +
+			"/orders  87   12  230ms\n"},
+		{FormatBorder, "" +
+			"┌──────────┬────────────────────┐\n" +
+			"│          │ Requests           │\n" +
+			"│ endpoint │ ok   │ err │ p99   │\n" +
+			"│ /users   │ 1204 │ 3   │ 41ms  │\n" +
+			"│ /orders  │ 87   │ 12  │ 230ms │\n" +
+			"└──────────┴──────┴─────┴───────┘\n"},
+		{FormatMarkdown, "" +
+			"|          | Requests |     |       |\n" +
+			"|----------|----------|-----|-------|\n" +
+			"| endpoint | ok       | err | p99   |\n" +
+			"| /users   | 1204     | 3   | 41ms  |\n" +
+			"| /orders  | 87       | 12  | 230ms |\n"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.SetOutputFormat(test.format)
+		w.WriteGroupHeader(Group{"", 1}, Group{"Requests", 3})
+		fmt.Fprint(w, "endpoint\tok\terr\tp99\n")
+		fmt.Fprint(w, "/users\t1204\t3\t41ms\n")
+		fmt.Fprint(w, "/orders\t87\t12\t230ms\n")
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("format %d: got:\n%s\nwant:\n%s", test.format, b.String(), test.want)
+		}
+	}
+}
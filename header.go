@@ -0,0 +1,132 @@
+package tabwriter
+
+// Underline is a style underlining text using ANSI escape sequences.
+var Underline = Style{"\x1b[4m", "\x1b[24m"}
+
+// UseFirstRowAsHeader sets whether the first row of each flush is treated as
+// the table's header. The header is output before the other rows and is
+// excluded from sorting, grouping and row limits. Its cells are emphasized
+// with the writer's header style, and name the columns in output formats that
+// label cells with their column's name, unless SetHeader names them.
+func (w *Writer) UseFirstRowAsHeader(enabled bool) {
+	w.firstRowHeader = enabled
+}
+
+// SetHeaderStyle sets the style used to emphasize the cells of the header
+// row when UseFirstRowAsHeader is enabled, such as Bold or Underline. Like
+// highlights, the style does not affect alignment. The zero Style disables
+// emphasis.
+func (w *Writer) SetHeaderStyle(style Style) {
+	w.headerStyle = style
+}
+
+// headerIndex returns the index of the buffered header row, or -1 if the
+// first row is not treated as the header.
+func (w *Writer) headerIndex() int {
+	if !w.firstRowHeader {
+		return -1
+	}
+	for i, l := range w.lines {
+		if l.raw.size == 0 && l.groups == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// withoutHeader calls f with the header row, and the raw text and group
+// headers preceding it, removed from the buffered lines.
+func (w *Writer) withoutHeader(f func()) {
+	h := w.headerIndex()
+	if h < 0 {
+		f()
+		return
+	}
+	head := w.lines[: h+1 : h+1]
+	w.lines = w.lines[h+1:]
+	f()
+	w.lines = append(head, w.lines...)
+}
+
+// markHeader marks the buffered header row.
+func (w *Writer) markHeader() {
+	if h := w.headerIndex(); h >= 0 {
+		w.lines[h].header = true
+	}
+}
+
+// styleHeader returns the text of a header cell emphasized with the writer's
+// header style.
+func (w *Writer) styleHeader(text []byte) []byte {
+	return w.headerStyle.apply(text)
+}
+
+// namedColumn holds the configuration of a column identified by name.
+type namedColumn struct {
+	name      string                   // name of the column
+	configure func(w *Writer, col int) // configures the column
+	col       int                      // index configured last (-1 = none)
+}
+
+// ConfigureColumn registers a function configuring the column named 'name',
+// so that configuration is unaffected when the producer of the rows reorders
+// its columns. The function is called with the column's index, and may call
+// any of the writer's per-column methods, as in:
+//
+//	w.ConfigureColumn("SIZE", func(w *Writer, col int) {
+//		w.SetColumnFormat(col, 0, 1, AlignRight)
+//	})
+//
+// The column's index is determined by the names given to SetHeader, and,
+// when UseFirstRowAsHeader is enabled, by the header row of each flush as
+// soon as it is complete. If the column moves, the settings of its previous
+// index are cleared before the function is called again, so columns
+// configured by name should not also be configured by index.
+func (w *Writer) ConfigureColumn(name string, configure func(w *Writer, col int)) {
+	w.namedColumns = append(w.namedColumns, namedColumn{name, configure, -1})
+	w.resolveColumnNames(w.headerNames)
+}
+
+// resolveColumnNames configures the columns registered by name that appear
+// in names.
+func (w *Writer) resolveColumnNames(names []string) {
+	for i := range w.namedColumns {
+		nc := &w.namedColumns[i]
+		col := indexOf(names, nc.name)
+		if col < 0 || col == nc.col {
+			continue
+		}
+		if nc.col >= 0 {
+			w.clearColumn(nc.col)
+		}
+		nc.configure(w, col)
+		nc.col = col
+	}
+}
+
+// clearColumn removes the format and settings of column col.
+func (w *Writer) clearColumn(col int) {
+	if col < len(w.columns) {
+		w.columns[col] = column{}
+	}
+	w.ClearColumnFormat(col)
+}
+
+// cellTexts returns the text of each of line l's cells.
+func (w *Writer) cellTexts(l line) []string {
+	texts := make([]string, len(l.cells))
+	for j, c := range l.cells {
+		texts[j] = string(w.cellText(c))
+	}
+	return texts
+}
+
+// indexOf returns the index of the first occurrence of s in list, or -1.
+func indexOf(list []string, s string) int {
+	for i, t := range list {
+		if t == s {
+			return i
+		}
+	}
+	return -1
+}
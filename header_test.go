@@ -0,0 +1,74 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestUseFirstRowAsHeader(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.UseFirstRowAsHeader(true)
+	w.SetHeaderStyle(Underline)
+	w.SortKeys([]SortKey{{Col: 1, Numeric: true}})
+	w.LimitRows(2, false)
+	fmt.Fprint(w, "NAME\tSIZE\n")
+	fmt.Fprint(w, "b.go\t300\n")
+	fmt.Fprint(w, "a.go\t12\n")
+	fmt.Fprint(w, "c.go\t45\n")
+	w.Flush()
+
+	want := "\x1b[4mNAME\x1b[24m \x1b[4mSIZE\x1b[24m\n" +
+		"a.go 12\n" +
+		"c.go 45\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestUseFirstRowAsHeaderGroupBy(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.UseFirstRowAsHeader(true)
+	w.GroupBy(0, Sum(1))
+	w.SetOutputFormat(FormatAccessible)
+	fmt.Fprint(w, "team\t2024\n")
+	fmt.Fprint(w, "red\t1\n")
+	fmt.Fprint(w, "blue\t2\n")
+	fmt.Fprint(w, "red\t3\n")
+	w.Flush()
+
+	want := "team: red; 2024: 4\n" +
+		"team: blue; 2024: 2\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestConfigureColumn(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.UseFirstRowAsHeader(true)
+	w.ConfigureColumn("SIZE", func(w *Writer, col int) {
+		w.SetColumnFormat(col, 0, 1, AlignRight)
+	})
+	fmt.Fprint(w, "NAME\tSIZE\tOWNER\n")
+	fmt.Fprint(w, "a.go\t12\troot\n")
+	fmt.Fprint(w, "b.go\t4500\tjo\n")
+	w.Flush()
+	fmt.Fprint(w, "SIZE\tNAME\tOWNER\n")
+	fmt.Fprint(w, "12\ta.go\troot\n")
+	fmt.Fprint(w, "4500\tb.go\tjo\n")
+	w.Flush()
+
+	want := "NAME SIZE OWNER\n" +
+		"a.go   12 root\n" +
+		"b.go 4500 jo\n" +
+		"SIZE NAME OWNER\n" +
+		"  12 a.go root\n" +
+		"4500 b.go jo\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
@@ -0,0 +1,148 @@
+package tabwriter
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// A Style describes how highlighted text is emphasized. Its prefix and
+// suffix, typically terminal escape sequences, are output around each
+// highlighted match and are not counted in the width of cells.
+type Style struct {
+	Prefix string // output before the highlighted text
+	Suffix string // output after the highlighted text
+}
+
+// Common styles using ANSI escape sequences.
+var (
+	Bold    = Style{"\x1b[1m", "\x1b[22m"}
+	Reverse = Style{"\x1b[7m", "\x1b[27m"}
+	Red     = Style{"\x1b[31m", "\x1b[39m"}
+	Green   = Style{"\x1b[32m", "\x1b[39m"}
+	Yellow  = Style{"\x1b[33m", "\x1b[39m"}
+)
+
+// apply returns text emphasized with style s. Empty text and the zero Style
+// leave text unchanged.
+func (s Style) apply(text []byte) []byte {
+	if len(text) == 0 || s == (Style{}) {
+		return text
+	}
+	out := append([]byte(s.Prefix), text...)
+	return append(out, s.Suffix...)
+}
+
+// highlight describes the text highlighted in cells.
+type highlight struct {
+	re    *regexp.Regexp // matches the highlighted text
+	style Style          // emphasizes the highlighted text
+}
+
+// Highlight emphasizes every occurrence of pattern in the output text of
+// cells with style. Alignment is unaffected, since the style's prefix and
+// suffix are added after cells have been measured. Only visible text is
+// matched: escape sequences, HTML tags and entities filtered by FilterHTML,
+// and text quoted with Escape are skipped. An empty pattern disables
+// highlighting. Each call replaces the previous highlight.
+func (w *Writer) Highlight(pattern string, style Style) {
+	if pattern == "" {
+		w.highlight = nil
+		return
+	}
+	w.HighlightRegexp(regexp.MustCompile(regexp.QuoteMeta(pattern)), style)
+}
+
+// HighlightRegexp emphasizes every match of the regular expression re in the
+// output text of cells with style. Empty matches are ignored. A nil re
+// disables highlighting.
+func (w *Writer) HighlightRegexp(re *regexp.Regexp, style Style) {
+	if re == nil {
+		w.highlight = nil
+		return
+	}
+	w.highlight = &highlight{re, style}
+}
+
+// highlightText returns text with the writer's highlight style applied to
+// each match. Only the visible text is matched: escape sequences, HTML tags
+// and entities when filtering HTML, and text quoted with Escape are skipped,
+// so that the style is never inserted within them. A match spanning several
+// runs of visible text is styled run by run.
+func (w *Writer) highlightText(text []byte) []byte {
+	h := w.highlight
+	if h == nil {
+		return text
+	}
+
+	// Match the concatenated visible runs, then map each match back to the
+	// parts of text it covers.
+	runs := w.visibleRuns(text)
+	var visible []byte
+	for _, r := range runs {
+		visible = append(visible, text[r[0]:r[1]]...)
+	}
+	var spans [][2]int
+	for _, m := range h.re.FindAllIndex(visible, -1) {
+		if m[0] == m[1] {
+			continue
+		}
+		offset := 0
+		for _, r := range runs {
+			start, end := max(m[0]-offset, 0), min(m[1]-offset, r[1]-r[0])
+			if start < end {
+				spans = append(spans, [2]int{r[0] + start, r[0] + end})
+			}
+			offset += r[1] - r[0]
+		}
+	}
+	if spans == nil {
+		return text
+	}
+
+	var out []byte
+	p := 0
+	for _, span := range spans {
+		out = append(out, text[p:span[0]]...)
+		out = append(out, h.style.Prefix...)
+		out = append(out, text[span[0]:span[1]]...)
+		out = append(out, h.style.Suffix...)
+		p = span[1]
+	}
+	return append(out, text[p:]...)
+}
+
+// visibleRuns returns the start and end offsets of the runs of text that are
+// output as is: the text outside escape sequences, HTML tags and entities
+// when filtering HTML, and text quoted with Escape.
+func (w *Writer) visibleRuns(text []byte) [][2]int {
+	var runs [][2]int
+	start := 0
+	for p := 0; p < len(text); {
+		skip := escapeSize(text[p:])
+		switch c := text[p]; {
+		case skip > 0:
+		case c == Escape:
+			skip = len(text) - p
+			if i := bytes.IndexByte(text[p+1:], Escape); i >= 0 {
+				skip = i + 2
+			}
+		case (c == '<' || c == '&') && w.format.flags&FilterHTML != 0:
+			skip = len(text) - p
+			if i := bytes.IndexByte(text[p+1:], htmlEnd(c)); i >= 0 {
+				skip = i + 2
+			}
+		default:
+			p++
+			continue
+		}
+		if start < p {
+			runs = append(runs, [2]int{start, p})
+		}
+		p += skip
+		start = p
+	}
+	if start < len(text) {
+		runs = append(runs, [2]int{start, len(text)})
+	}
+	return runs
+}
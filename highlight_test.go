@@ -0,0 +1,71 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	style := Style{"[", "]"}
+	tests := []struct {
+		set  func(w *Writer)
+		want string
+	}{
+		{
+			func(w *Writer) { w.Highlight("err", style) },
+			"[err]or     a.b\n" +
+				"ok        [err]\n",
+		},
+		{
+			func(w *Writer) { w.HighlightRegexp(regexp.MustCompile(`a\.b|^ok`), style) },
+			"error     [a.b]\n" +
+				"[ok]        err\n",
+		},
+		{
+			func(w *Writer) { w.Highlight(".", style) },
+			"error     a[.]b\n" +
+				"ok        err\n",
+		},
+	}
+
+	for i, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 10, 8, 1, ' ', 0)
+		test.set(w)
+		fmt.Fprint(w, "error\ta.b\n")
+		fmt.Fprint(w, "ok\terr\n")
+		w.Flush()
+		if b.String() != test.want {
+			t.Errorf("test %d: got:\n%s\nwant:\n%s", i, b.String(), test.want)
+		}
+	}
+}
+
+func TestHighlightInvisible(t *testing.T) {
+	style := Style{"[", "]"}
+	tests := []struct {
+		flags   uint
+		pattern string
+		in      string
+		want    string
+	}{
+		{0, "3", "\x1b[31mred3\x1b[0m\tx\n", "\x1b[31mred[3]\x1b[0m x\n"},
+		{0, "d3", "\x1b[31mred\x1b[0m3\tx\n", "\x1b[31mre[d]\x1b[0m[3] x\n"},
+		{0, "3", "\x1b]8;;http://3.example\x1b\\link\x1b]8;;\x1b\\\tx\n", "\x1b]8;;http://3.example\x1b\\link\x1b]8;;\x1b\\ x\n"},
+		{FilterHTML, "3", "<b3>3</b3>&#3;\tx\n", "<b3>[3]</b3>&#3; x\n"},
+		{0, "3", "\xff3\xff3\tx\n", "\xff3\xff[3] x\n"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', test.flags)
+		w.Highlight(test.pattern, style)
+		fmt.Fprint(w, test.in)
+		w.Flush()
+		if b.String() != test.want {
+			t.Errorf("%q: got %q, want %q", test.in, b.String(), test.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package tabwriter
+
+// NewIndentedWriter creates a writer for a table nested in the output of
+// parent, such as a sub-table in a command's help text. Each line the child
+// outputs is preceded by prefix and written to parent as raw text, so it
+// appears among the parent's rows without affecting their alignment. The
+// child uses the parent's default cell format, and its width budget is the
+// parent's description wrap width, or the parent terminal's width if
+// descriptions are not wrapped, less the width of prefix. Rows wider than the
+// budget are wrapped as by SetRowFormat, using the parent's row prefix.
+// Flushing the parent flushes the child first.
+func NewIndentedWriter(parent *Writer, prefix string) *Writer {
+	f := parent.format
+	w := NewWriter(rawWriter{parent}, f.minwidth, parent.tabwidth, f.padding, parent.padchar, f.flags)
+	w.SetLineTransformers(func(line []byte) []byte {
+		return append([]byte(prefix), line...)
+	})
+
+	width := parent.formatDescription.wordwrap
+	if width <= 0 && parent.terminal != nil {
+		width = parent.terminal.Width()
+	}
+	if width > 0 {
+		width = max(width-w.measure([]byte(prefix)), 1)
+		w.SetTerminal(FixedWidth(width))
+		w.SetRowFormat(width, parent.formatRow.prefix)
+		w.SetDescriptionFormat(min(parent.formatDescription.indent, width-1), width)
+	}
+
+	parent.children = append(parent.children, w)
+	return w
+}
+
+// rawWriter is an io.Writer that writes to a Writer as raw text.
+type rawWriter struct {
+	w *Writer
+}
+
+func (r rawWriter) Write(p []byte) (int, error) {
+	r.w.WriteRaw(p)
+	return len(p), nil
+}
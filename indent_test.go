@@ -0,0 +1,34 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestNewIndentedWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 2, ' ', 0)
+	w.SetDescriptionFormat(4, 30)
+	w.SetRowFormat(0, "      ")
+	sub := NewIndentedWriter(w, "    ")
+
+	fmt.Fprint(w, "-format\tstring\n")
+	fmt.Fprint(sub, "text\taligned columns\tdefault\n")
+	fmt.Fprint(sub, "json\trecords\n")
+	sub.Flush()
+	fmt.Fprint(w, "-v\tbool\n")
+	w.Flush()
+
+	want := "-format  string\n" +
+		"    text  aligned columns\n" +
+		"          default\n" +
+		"    json  records\n" +
+		"-v       bool\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+	if width := sub.terminal.Width(); width != 26 {
+		t.Errorf("sub-table width = %d, want 26", width)
+	}
+}
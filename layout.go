@@ -0,0 +1,634 @@
+package tabwriter
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"unicode"
+)
+
+// A Sizing computes the width of a column's text from the text widths of the
+// cells it contains. Cells wider than the computed width are handled
+// according to the column's overflow setting.
+type Sizing func(widths []int) int
+
+// MaxContent sizes a column to fit the text of all its cells. It is the
+// default sizing for every column.
+func MaxContent(widths []int) int {
+	width := 0
+	for _, w := range widths {
+		width = max(width, w)
+	}
+	return width
+}
+
+// Percentile returns a sizing that fits p percent of a column's cells,
+// leaving the widest cells to overflow. It produces denser tables than
+// MaxContent for columns with a few unusually wide values.
+func Percentile(p float64) Sizing {
+	return func(widths []int) int {
+		if len(widths) == 0 {
+			return 0
+		}
+		sorted := append([]int(nil), widths...)
+		sort.Ints(sorted)
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		return sorted[min(max(rank, 1), len(sorted))-1]
+	}
+}
+
+// SoftMax returns a sizing that limits a column's text to width columns, as
+// long as no more than percent percent of its cells are wider. Otherwise, the
+// column is sized to fit all of its cells.
+func SoftMax(width, percent int) Sizing {
+	return func(widths []int) int {
+		wider := 0
+		for _, w := range widths {
+			if w > width {
+				wider++
+			}
+		}
+		if wider*100 > percent*len(widths) {
+			return MaxContent(widths)
+		}
+		return min(width, MaxContent(widths))
+	}
+}
+
+// An Overflow determines how cells too wide for their column are output.
+type Overflow int
+
+const (
+	// OverflowTruncate truncates the text of a cell that is too wide and
+	// ends it with an ellipsis.
+	OverflowTruncate Overflow = iota
+
+	// OverflowWrap wraps the text of a cell that is too wide onto additional
+	// lines, using the column's break policy.
+	OverflowWrap
+)
+
+// defaultEllipsis marks the end of truncated text unless SetEllipsis is
+// called.
+const defaultEllipsis = "…"
+
+// A CellSpec describes a cell whose column width is to be computed by
+// ComputeLayout.
+type CellSpec struct {
+	Width int // display width of the cell's text
+}
+
+// A Layout holds the column widths computed by ComputeLayout.
+type Layout struct {
+	// Widths holds the width of each cell's column, including padding, for
+	// each row of cells. A cell's text should be padded to its column's
+	// width according to the column's alignment.
+	Widths [][]int
+}
+
+// ComputeLayout computes the column widths a Writer configured with cfg
+// would use to align rows of cells, without producing any output. It allows
+// other kinds of renderers to reuse the Writer's alignment logic.
+func ComputeLayout(rows [][]CellSpec, cfg Config) Layout {
+	return NewWriterConfig(nil, cfg).ComputeLayout(rows)
+}
+
+// ComputeLayout computes the column widths the writer would use to align
+// rows of cells, including the effects of all of its column settings,
+// without producing any output. The writer's buffered text is unaffected.
+func (w *Writer) ComputeLayout(rows [][]CellSpec) Layout {
+	t := w.Clone(nil)
+	t.lines = t.lines[:0]
+	t.flushFormat = t.currentFormat()
+	for _, row := range rows {
+		l := line{cells: make([]cell, len(row))}
+		for j, spec := range row {
+			l.cells[j] = cell{width: spec.Width, term: j == len(row)-1}
+		}
+		t.lines = append(t.lines, l)
+	}
+
+	if t.grid() {
+		t.layoutGrid()
+	} else {
+		t.layout()
+	}
+
+	layout := Layout{Widths: make([][]int, len(t.lines))}
+	for i, l := range t.lines {
+		layout.Widths[i] = make([]int, len(l.cells))
+		for j, c := range l.cells {
+			layout.Widths[i][j] = c.maxwidth
+		}
+	}
+	return layout
+}
+
+// layout computes the width of every cell's column.
+//
+// The cells of a column are sized together in blocks. A block consists of
+// the cells in a run of consecutive lines for which the column is not the
+// line's last cell. Every cell in a block has the block's width, and a line's
+// last cell is at least as wide as the block ending just above it. Blocks
+// also end where the lines start a new section.
+func (w *Writer) layout() {
+	first, ncols := -1, 0
+	for i, l := range w.lines {
+		if l.raw.size > 0 || l.groups != nil {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		ncols = max(ncols, len(l.cells))
+	}
+
+	tabs := w.padchar == '\t'
+	sections := w.sectionStarts()
+	for col := 0; col < ncols; col++ {
+		var block []*cell
+		start := -1
+		for i := range w.lines {
+			l := &w.lines[i]
+			if l.raw.size > 0 || l.groups != nil {
+				continue
+			}
+
+			if sections[i] && block != nil {
+				w.sizeBlock(col, block, tabs && (len(block) > 1 || start != first))
+				block = nil
+			}
+
+			last := len(l.cells) - 1
+			if col < last {
+				if block == nil {
+					start = i
+				}
+				block = append(block, &l.cells[col])
+				continue
+			}
+
+			// Adjust column widths to hit tab stops on all but the first
+			// line.
+			width := w.sizeBlock(col, block, tabs && (len(block) > 1 || start != first))
+			if col == last {
+				c := &l.cells[col]
+				c.maxwidth = max(w.cellWidth(c, col, w.textWidth(c, col)), width)
+				if tabs && i != first {
+					c.maxwidth = w.tabify(c.maxwidth)
+				}
+			}
+			block = nil
+		}
+		w.sizeBlock(col, block, tabs && (len(block) > 1 || start != first))
+	}
+}
+
+// SetSectionThreshold starts a new alignment section whenever the number of
+// cells in a row differs from the number in the row above it by at least n,
+// so that the columns of heterogeneous text, such as a block of key-value
+// pairs followed by a table and some notes, are aligned separately without
+// explicit form feeds. Unlike a form feed, the new section doesn't flush the
+// buffered rows. Sections only affect the text output format. A threshold of
+// 0 disables automatic sections.
+func (w *Writer) SetSectionThreshold(n int) {
+	w.sectionThreshold = n
+}
+
+// sectionStarts returns, for each buffered line, whether it starts a new
+// alignment section because its number of cells differs too much from that
+// of the row above it.
+func (w *Writer) sectionStarts() []bool {
+	starts := make([]bool, len(w.lines))
+	if w.sectionThreshold <= 0 {
+		return starts
+	}
+
+	prev := -1
+	for i, l := range w.lines {
+		if l.raw.size > 0 || l.groups != nil {
+			continue
+		}
+		n := len(l.cells)
+		if prev >= 0 && (n-prev >= w.sectionThreshold || prev-n >= w.sectionThreshold) {
+			starts[i] = true
+			w.tracef("line %d: started a new section of %d cells", i, n)
+		}
+		prev = n
+	}
+	return starts
+}
+
+// sizeBlock computes the width of a block of cells in column col, assigns it
+// to each of the cells and returns it.
+func (w *Writer) sizeBlock(col int, block []*cell, tabify bool) int {
+	if len(block) == 0 {
+		return 0
+	}
+
+	limit, overflow := w.blockLimit(col, block)
+
+	width, empty := 0, true
+	for _, c := range block {
+		textwidth := w.textWidth(c, col)
+		if limit > 0 && textwidth > limit && !c.block {
+			w.overflowCell(c, col, limit, overflow)
+			textwidth = limit
+		}
+		width = max(width, w.cellWidth(c, col, textwidth))
+		empty = empty && textwidth == 0
+	}
+	switch {
+	case empty && w.format.flags&DiscardEmptyColumns != 0 && !w.grid():
+		width = 0
+		w.tracef("column %d: discarded %d empty cells", col, len(block))
+	case tabify:
+		width = w.tabify(width)
+	}
+
+	for _, c := range block {
+		c.maxwidth = width
+	}
+	w.tracef("column %d: sized %d cells to width %d", col, len(block), width)
+	return width
+}
+
+// blockLimit returns the maximum text width allowed for the cells of a block
+// in column col, or 0 if the cells' text width is not limited. It also
+// returns how cells wider than the limit are to be output.
+func (w *Writer) blockLimit(col int, block []*cell) (int, Overflow) {
+	column := w.getColumn(col)
+	wrapped := column.minContent && column.wrap.width > 0 && column.wrap.policy != BreakNone
+	if column.sizing == nil && !wrapped {
+		return 0, column.overflow
+	}
+
+	widths := make([]int, len(block))
+	for i, c := range block {
+		widths[i] = w.textWidth(c, col)
+	}
+	limit, overflow := MaxContent(widths), column.overflow
+	if column.sizing != nil {
+		limit = min(limit, column.sizing(widths))
+	}
+
+	// Min-content sizing narrows the column to its longest word, wrapping
+	// the cells' text onto additional lines.
+	if wrapped {
+		words := 0
+		for _, c := range block {
+			if c.size == 0 {
+				words = max(words, c.width)
+			} else {
+				words = max(words, longestWord(w.cellText(*c), column.wrap.policy, w.measure))
+			}
+		}
+		if words < limit {
+			limit, overflow = words, OverflowWrap
+		}
+	}
+
+	if limit < MaxContent(widths) {
+		return max(limit, 1), overflow
+	}
+	return 0, overflow
+}
+
+// textWidth returns the width of cell c's text for the purpose of sizing
+// column col. Cells overflowing the column's wrap width count only as wide
+// as the wrap width, unless they are pre-rendered blocks.
+func (w *Writer) textWidth(c *cell, col int) int {
+	if wrap := w.getColumn(col).wrap; wrap.width > 0 && wrap.policy == BreakNone && !c.block {
+		return min(c.width, wrap.width)
+	}
+	return c.width
+}
+
+// cellWidth returns the width of a cell in column col containing textwidth
+// columns of text, including padding. The cell c may be nil.
+func (w *Writer) cellWidth(c *cell, col int, textwidth int) int {
+	format := w.getFormat(col)
+	extra := 0
+	if c != nil {
+		extra = c.extra
+	}
+	width := max(format.minwidth, textwidth+format.padding+extra)
+	return max(width, w.getColumn(col).reserve+format.padding)
+}
+
+// overflowCell makes cell c, which is too wide for column col, fit within
+// width columns.
+func (w *Writer) overflowCell(c *cell, col int, width int, overflow Overflow) {
+	text := string(w.cellText(*c))
+	switch overflow {
+	case OverflowWrap:
+		w.wrapCell(c, width, w.getColumn(col).wrap.policy)
+		w.tracef("column %d: wrapped %q at width %d onto %d lines", col, text, width, len(c.segs))
+	default:
+		w.truncateCell(c, width)
+		w.tracef("column %d: truncated %q to width %d", col, text, width)
+	}
+}
+
+// truncateColumns truncates the cells wider than their column's maximum
+// width.
+func (w *Writer) truncateColumns() {
+	switch w.flushFormat {
+	case FormatHTML, FormatJSON, FormatTSV:
+		return
+	}
+	for i := range w.lines {
+		for j := range w.lines[i].cells {
+			c := &w.lines[i].cells[j]
+			if limit := w.getColumn(j).maxWidth; limit > 0 && c.width > limit && !c.block {
+				w.truncateCell(c, limit)
+				w.tracef("column %d: truncated cell to maximum width %d", j, limit)
+			}
+		}
+	}
+}
+
+// truncateCell shortens cell c's text to width columns, replacing the end of
+// its text with the writer's ellipsis. The shortened text is appended to the
+// buffer.
+func (w *Writer) truncateCell(c *cell, width int) {
+	text := cutText(w.cellText(*c), width-w.ellipsisWidth(), w.measure)
+	text = append(append([]byte(nil), text...), w.ellipsis...)
+
+	c.start = w.buf.Len()
+	c.size = len(text)
+	c.width = w.measure(text)
+	c.segs = nil
+	w.buf.Write(text)
+}
+
+// cutText returns the longest prefix of text that is no wider than width
+// columns. If the prefix contains escape sequences, such as colors, it ends
+// with a sequence resetting all graphic attributes, and it closes any
+// hyperlink the text was cut in. The width of the text's grapheme clusters
+// is computed by measure.
+func cutText(text []byte, width int, measure func([]byte) int) []byte {
+	n, p, escaped, link := 0, 0, false, false
+	for p < len(text) {
+		if size := escapeSize(text[p:]); size > 0 {
+			if seq := text[p : p+size]; bytes.HasPrefix(seq, oscLink) {
+				seq = bytes.TrimSuffix(bytes.TrimSuffix(seq, []byte{0x07}), []byte("\x1b\\"))
+				link = !bytes.HasSuffix(seq, []byte{';'})
+			}
+			p += size
+			escaped = true
+			continue
+		}
+		_, size, _ := nextCluster(text[p:])
+		rw := measure(text[p : p+size])
+		if n+rw > width {
+			break
+		}
+		p += size
+		n += rw
+	}
+	if escaped && p < len(text) {
+		prefix := append(append([]byte(nil), text[:p]...), sgrReset...)
+		if link {
+			prefix = append(append(prefix, oscLink...), ";\x1b\\"...)
+		}
+		return prefix
+	}
+	return text[:p]
+}
+
+// ellipsisWidth returns the width of the writer's ellipsis.
+func (w *Writer) ellipsisWidth() int {
+	return w.measure([]byte(w.ellipsis))
+}
+
+// SetWidthFunc sets the function used to compute the number of columns text
+// occupies when output, which by default accounts for escape sequences,
+// invisible characters, East Asian wide characters and grapheme clusters. It
+// allows callers to handle terminal quirks the writer doesn't know about.
+// Cell text is wrapped and truncated between grapheme clusters, which are
+// measured individually, while escape sequences are never broken and always
+// measured as a whole. A nil function restores the default.
+func (w *Writer) SetWidthFunc(fn func(text []byte) int) {
+	w.widthFunc = fn
+}
+
+// measure returns the number of columns text occupies when output.
+func (w *Writer) measure(text []byte) int {
+	measure := displayWidth
+	if w.widthFunc != nil {
+		measure = w.widthFunc
+	}
+	if bytes.IndexByte(text, Escape) >= 0 {
+		text = bytes.Replace(text, []byte{Escape}, nil, -1)
+	}
+	if w.format.flags&FilterHTML != 0 {
+		return htmlWidth(text, measure)
+	}
+	return measure(text)
+}
+
+// htmlWidth returns the number of columns text occupies when output, with
+// HTML tags occupying none and each entity occupying one. The width of the
+// rest of the text is computed by measure.
+func htmlWidth(text []byte, measure func([]byte) int) int {
+	width := 0
+	for len(text) > 0 {
+		i := bytes.IndexAny(text, "<&")
+		if i < 0 {
+			return width + measure(text)
+		}
+		width += measure(text[:i])
+
+		end := htmlEnd(text[i])
+		if text[i] == '&' {
+			width++
+		}
+		j := bytes.IndexByte(text[i+1:], end)
+		if j < 0 {
+			break
+		}
+		text = text[i+j+2:]
+	}
+	return width
+}
+
+// htmlEnd returns the character ending the HTML tag or entity starting with
+// ch.
+func htmlEnd(ch byte) byte {
+	if ch == '<' {
+		return '>'
+	}
+	return ';'
+}
+
+// displayWidth returns the number of columns text occupies when output.
+// ANSI escape sequences, such as those setting colors, occupy none, and each
+// grapheme cluster occupies the width of a single glyph.
+func displayWidth(text []byte) int {
+	width := 0
+	for p := 0; p < len(text); {
+		if n := escapeSize(text[p:]); n > 0 {
+			p += n
+			continue
+		}
+		_, size, n := nextCluster(text[p:])
+		width += n
+		p += size
+	}
+	return width
+}
+
+// DisplayWidth returns the number of columns text occupies when output, as
+// measured by a Writer without a width function set by SetWidthFunc. Escape
+// sequences occupy none, and each grapheme cluster occupies the width of a
+// single glyph, which is two for East Asian wide characters.
+func DisplayWidth(text string) int {
+	return displayWidth([]byte(text))
+}
+
+// sgrReset is the escape sequence resetting all graphic attributes.
+var sgrReset = []byte("\x1b[0m")
+
+// oscLink starts the OSC 8 escape sequences that begin and end hyperlinks.
+var oscLink = []byte("\x1b]8;")
+
+// escapeSize returns the size of the ANSI escape sequence at the start of
+// text, or 0 if text doesn't start with a complete one. It recognizes control
+// sequences (CSI), such as the SGR sequence "\x1b[31m" selecting a red
+// foreground, and operating system commands (OSC), such as the OSC 8
+// sequences "\x1b]8;;URL\x1b\\" surrounding the text of a hyperlink.
+func escapeSize(text []byte) int {
+	if len(text) < 2 || text[0] != 0x1b {
+		return 0
+	}
+	switch text[1] {
+	case '[':
+		for i := 2; i < len(text); i++ {
+			switch c := text[i]; {
+			case c >= 0x40 && c <= 0x7e:
+				return i + 1 // final byte
+			case c < 0x20 || c > 0x3f:
+				return 0
+			}
+		}
+	case ']':
+		// An OSC ends with a string terminator (ESC '\') or a BEL.
+		for i := 2; i < len(text); i++ {
+			switch text[i] {
+			case 0x07:
+				return i + 1
+			case 0x1b:
+				if i+1 < len(text) && text[i+1] == '\\' {
+					return i + 2
+				}
+				return 0
+			}
+		}
+	}
+	return 0
+}
+
+// runeWidth returns the number of columns rune r occupies when output.
+// Invisible format characters, such as zero-width spaces, zero-width joiners
+// and soft hyphens, occupy none, while East Asian wide and fullwidth
+// characters, such as CJK ideographs, occupy two.
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Cf, r):
+		return 0
+	case unicode.Is(eastAsianWide, r):
+		return 2
+	}
+	return 1
+}
+
+// eastAsianWide holds the characters that occupy two columns of a terminal:
+// the wide and fullwidth characters of Unicode's East Asian Width property,
+// such as CJK ideographs, Hangul syllables, fullwidth forms and emoji.
+var eastAsianWide = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x1100, 0x115f, 1},
+		{0x231a, 0x231b, 1},
+		{0x2329, 0x232a, 1},
+		{0x23e9, 0x23ec, 1},
+		{0x23f0, 0x23f3, 3},
+		{0x25fd, 0x25fe, 1},
+		{0x2614, 0x2615, 1},
+		{0x2648, 0x2653, 1},
+		{0x267f, 0x2693, 20},
+		{0x26a1, 0x26aa, 9},
+		{0x26ab, 0x26bd, 18},
+		{0x26be, 0x26c4, 6},
+		{0x26c5, 0x26ce, 9},
+		{0x26d4, 0x26ea, 22},
+		{0x26f2, 0x26f3, 1},
+		{0x26f5, 0x26fa, 5},
+		{0x26fd, 0x2705, 8},
+		{0x270a, 0x270b, 1},
+		{0x2728, 0x274c, 36},
+		{0x274e, 0x2753, 5},
+		{0x2754, 0x2755, 1},
+		{0x2757, 0x2795, 62},
+		{0x2796, 0x2797, 1},
+		{0x27b0, 0x27bf, 15},
+		{0x2b1b, 0x2b1c, 1},
+		{0x2b50, 0x2b55, 5},
+		{0x2e80, 0x303e, 1},
+		{0x3041, 0x33ff, 1},
+		{0x3400, 0x4dbf, 1},
+		{0x4e00, 0xa4cf, 1},
+		{0xa960, 0xa97f, 1},
+		{0xac00, 0xd7a3, 1},
+		{0xf900, 0xfaff, 1},
+		{0xfe10, 0xfe19, 1},
+		{0xfe30, 0xfe6f, 1},
+		{0xff00, 0xff60, 1},
+		{0xffe0, 0xffe6, 1},
+	},
+	R32: []unicode.Range32{
+		{0x16fe0, 0x16fe4, 1},
+		{0x17000, 0x18cff, 1},
+		{0x1b000, 0x1b2ff, 1},
+		{0x1f004, 0x1f0cf, 203},
+		{0x1f18e, 0x1f191, 3},
+		{0x1f192, 0x1f19a, 1},
+		{0x1f200, 0x1f202, 1},
+		{0x1f210, 0x1f23b, 1},
+		{0x1f240, 0x1f248, 1},
+		{0x1f250, 0x1f251, 1},
+		{0x1f260, 0x1f265, 1},
+		{0x1f300, 0x1f320, 1},
+		{0x1f32d, 0x1f335, 1},
+		{0x1f337, 0x1f37c, 1},
+		{0x1f37e, 0x1f393, 1},
+		{0x1f3a0, 0x1f3ca, 1},
+		{0x1f3cf, 0x1f3d3, 1},
+		{0x1f3e0, 0x1f3f0, 1},
+		{0x1f3f4, 0x1f3f8, 4},
+		{0x1f3f9, 0x1f43e, 1},
+		{0x1f440, 0x1f442, 2},
+		{0x1f443, 0x1f4fc, 1},
+		{0x1f4ff, 0x1f53d, 1},
+		{0x1f54b, 0x1f54e, 1},
+		{0x1f550, 0x1f567, 1},
+		{0x1f57a, 0x1f595, 27},
+		{0x1f596, 0x1f5a4, 14},
+		{0x1f5fb, 0x1f64f, 1},
+		{0x1f680, 0x1f6c5, 1},
+		{0x1f6cc, 0x1f6d0, 4},
+		{0x1f6d1, 0x1f6d2, 1},
+		{0x1f6d5, 0x1f6d7, 1},
+		{0x1f6dc, 0x1f6df, 1},
+		{0x1f6eb, 0x1f6ec, 1},
+		{0x1f6f4, 0x1f6fc, 1},
+		{0x1f7e0, 0x1f7eb, 1},
+		{0x1f7f0, 0x1f90c, 284},
+		{0x1f90d, 0x1f93a, 1},
+		{0x1f93c, 0x1f945, 1},
+		{0x1f947, 0x1f9ff, 1},
+		{0x1fa70, 0x1faff, 1},
+		{0x20000, 0x2fffd, 1},
+		{0x30000, 0x3fffd, 1},
+	},
+}
@@ -0,0 +1,319 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestColumnSoftMax(t *testing.T) {
+	rows := []string{
+		"a\tshort\tx\n",
+		"b\tmedium text\tx\n",
+		"c\tthis value is pathologically long\tx\n",
+		"d\ttiny\tx\n",
+	}
+
+	tests := []struct {
+		percent  int
+		overflow Overflow
+		want     string
+	}{
+		{
+			25, OverflowTruncate,
+			"a short       x\n" +
+				"b medium text x\n" +
+				"c this value… x\n" +
+				"d tiny        x\n",
+		},
+		{
+			25, OverflowWrap,
+			"a short       x\n" +
+				"b medium text x\n" +
+				"c this value  x\n" +
+				"  is\n" +
+				"  pathologica\n" +
+				"  lly long\n" +
+				"d tiny        x\n",
+		},
+		{
+			10, OverflowTruncate,
+			"a short                             x\n" +
+				"b medium text                       x\n" +
+				"c this value is pathologically long x\n" +
+				"d tiny                              x\n",
+		},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.SetColumnSoftMax(1, 11, test.percent, test.overflow)
+		for _, r := range rows {
+			fmt.Fprint(w, r)
+		}
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("percent %d: got:\n%s\nwant:\n%s", test.percent, b.String(), test.want)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	widths := []int{3, 9, 4, 5, 40, 6, 2, 7, 8, 1}
+	tests := []struct {
+		p     float64
+		width int
+	}{
+		{100, 40},
+		{90, 9},
+		{50, 5},
+		{10, 1},
+		{0, 1},
+	}
+
+	for _, test := range tests {
+		if width := Percentile(test.p)(widths); width != test.width {
+			t.Errorf("Percentile(%v) = %d, want %d", test.p, width, test.width)
+		}
+	}
+}
+
+func TestColumnSizing(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnSizing(0, Percentile(75))
+	w.SetColumnOverflow(0, OverflowTruncate)
+	fmt.Fprint(w, "alpha\t1\n")
+	fmt.Fprint(w, "beta\t2\n")
+	fmt.Fprint(w, "gamma-delta-epsilon\t3\n")
+	fmt.Fprint(w, "pi\t4\n")
+	w.Flush()
+
+	want := "alpha 1\n" +
+		"beta  2\n" +
+		"gamm… 3\n" +
+		"pi    4\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSetEllipsis(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetEllipsis("...")
+	w.SetColumnSizing(0, Percentile(75))
+	w.SetColumnOverflow(0, OverflowTruncate)
+	fmt.Fprint(w, "alpha\t1\n")
+	fmt.Fprint(w, "beta\t2\n")
+	fmt.Fprint(w, "gamma-delta-epsilon\t3\n")
+	fmt.Fprint(w, "pi\t4\n")
+	w.Flush()
+
+	want := "alpha 1\n" +
+		"beta  2\n" +
+		"ga... 3\n" +
+		"pi    4\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestColumnMaxWidth(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnMaxWidth(0, 8)
+	w.SetColumnMaxWidth(2, 6)
+	fmt.Fprint(w, "id\tname\tnote\n")
+	fmt.Fprint(w, "a1\tweb\tok\n")
+	fmt.Fprint(w, "b2-pathologically-long\tdb\tdegraded performance\n")
+	w.Flush()
+
+	want := "id       name note\n" +
+		"a1       web  ok\n" +
+		"b2-path… db   degra…\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestEscapeSequenceWidth(t *testing.T) {
+	red := func(s string) string { return "\x1b[31m" + s + "\x1b[0m" }
+
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnMaxWidth(2, 7)
+	fmt.Fprint(w, "NAME\tSTATUS\tMESSAGE\n")
+	fmt.Fprint(w, "web\t"+red("failed")+"\t"+red("connection refused")+"\n")
+	fmt.Fprint(w, "db\tok\tfine\n")
+	w.Flush()
+
+	want := "NAME STATUS MESSAGE\n" +
+		"web  " + red("failed") + " \x1b[31mconnec\x1b[0m…\n" +
+		"db   ok     fine\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	if got := displayWidth([]byte("\x1b[1;38;5;208mbold\x1b[22m\x1b[")); got != 6 {
+		t.Errorf("displayWidth: got %d, want 6", got)
+	}
+}
+
+func TestHyperlinkWidth(t *testing.T) {
+	link := func(url, s string) string { return "\x1b]8;;" + url + "\x1b\\" + s + "\x1b]8;;\x1b\\" }
+
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnMaxWidth(1, 5)
+	fmt.Fprint(w, "issue\ttitle\tstate\n")
+	fmt.Fprint(w, link("https://example.com/1", "#1")+"\t"+link("https://example.com/1", "crash")+"\topen\n")
+	fmt.Fprint(w, "#12\t"+link("https://example.com/12", "slow start")+"\tclosed\n")
+	w.Flush()
+
+	want := "issue title state\n" +
+		link("https://example.com/1", "#1") + "    " + link("https://example.com/1", "crash") + " open\n" +
+		"#12   \x1b]8;;https://example.com/12\x1b\\slow\x1b[0m\x1b]8;;\x1b\\… closed\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	if got := displayWidth([]byte("\x1b]8;;http://a.b\x07ab\x1b]8;;\x07")); got != 2 {
+		t.Errorf("displayWidth: got %d, want 2", got)
+	}
+}
+
+func TestEastAsianWidth(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnMaxWidth(1, 6)
+	fmt.Fprint(w, "city\tname\tpop\n")
+	fmt.Fprint(w, "tokyo\t東京都\t14\n")
+	fmt.Fprint(w, "seoul\t서울\t9.7\n")
+	fmt.Fprint(w, "osaka\t大阪府大阪市\t2.7\n")
+	w.Flush()
+
+	want := "city  name   pop\n" +
+		"tokyo 東京都 14\n" +
+		"seoul 서울   9.7\n" +
+		"osaka 大阪…  2.7\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	if got := displayWidth([]byte("ｗｉｄｅ🙂a")); got != 11 {
+		t.Errorf("displayWidth: got %d, want 11", got)
+	}
+}
+
+func TestSetWidthFunc(t *testing.T) {
+	// A terminal displaying the ambiguous-width check marks as wide.
+	ambiguous := func(text []byte) int {
+		return displayWidth(text) + bytes.Count(text, []byte("✓"))
+	}
+
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetWidthFunc(ambiguous)
+	w.SetColumnMaxWidth(1, 5)
+	fmt.Fprint(w, "ok\tcheck\tx\n")
+	fmt.Fprint(w, "✓\t✓✓✓\tx\n")
+	w.Flush()
+
+	want := "ok check x\n" +
+		"✓ ✓✓… x\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSectionThreshold(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetSectionThreshold(2)
+	fmt.Fprint(w, "Host:\texample.com\t\n")
+	fmt.Fprint(w, "Uptime:\t12 days\t\n")
+	fmt.Fprint(w, "pid\tuser\tcpu\tmem\tcommand\n")
+	fmt.Fprint(w, "1\troot\t0.1\t2.4\tinit\n")
+	fmt.Fprint(w, "4211\tgopher\t87.5\t10.2\tbuild\n")
+	fmt.Fprint(w, "Note:\tcpu is a percentage\n")
+	w.Flush()
+
+	want := "Host:   example.com\n" +
+		"Uptime: 12 days\n" +
+		"pid  user   cpu  mem  command\n" +
+		"1    root   0.1  2.4  init\n" +
+		"4211 gopher 87.5 10.2 build\n" +
+		"Note: cpu is a percentage\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestComputeLayout(t *testing.T) {
+	rows := [][]CellSpec{
+		{{Width: 1}, {Width: 5}, {Width: 2}},
+		{{Width: 4}, {Width: 2}},
+		{},
+		{{Width: 3}, {Width: 3}},
+	}
+	cfg := Defaults()
+
+	layout := ComputeLayout(rows, cfg)
+	want := [][]int{
+		{5, 6, 3},
+		{5, 6},
+		{},
+		{4, 4},
+	}
+	if fmt.Sprint(layout.Widths) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", layout.Widths, want)
+	}
+
+	var b bytes.Buffer
+	w := NewWriterConfig(&b, cfg)
+	w.SetOutputFormat(FormatBorder)
+	fmt.Fprint(w, "pending\t")
+	layout = w.ComputeLayout(rows)
+	want = [][]int{
+		{5, 6, 3},
+		{5, 6},
+		{},
+		{5, 6},
+	}
+	if fmt.Sprint(layout.Widths) != fmt.Sprint(want) {
+		t.Errorf("grid: got %v, want %v", layout.Widths, want)
+	}
+
+	w.Flush()
+	if b.String() != "┌─────────┐\n│ pending │\n└─────────┘\n" {
+		t.Errorf("buffered text changed: %q", b.String())
+	}
+}
+
+func TestRenderRows(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, AlignRight)
+	w.SetColumnWrap(2, 5, BreakWord)
+	fmt.Fprint(w, "name\tsize\tnote\n")
+	fmt.Fprint(w, "a.go\t120\tsmall file\n")
+	fmt.Fprint(w, "main.go\t4096\tok\n")
+
+	want := [][]string{
+		{"name    ", "size ", "note"},
+		{"a.go    ", " 120 ", "small"},
+		{"        ", "     ", "file"},
+		{"main.go ", "4096 ", "ok"},
+	}
+	got := w.RenderRows()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if b.Len() != 0 {
+		t.Errorf("unexpected output %q", b.String())
+	}
+}
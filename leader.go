@@ -0,0 +1,87 @@
+package tabwriter
+
+import (
+	"io"
+	"strings"
+)
+
+// NewTOCWriter creates a writer that formats rows of two cells, such as the
+// titles and page numbers of a table of contents, as lines width columns
+// wide: the first cell is followed by leader dots, and the last cell is
+// right-aligned.
+//
+//	Introduction ................ 1
+//	Getting started ............. 5
+//	Reference .................. 42
+func NewTOCWriter(output io.Writer, width int) *Writer {
+	w := NewWriter(output, 0, 8, 1, ' ', 0)
+	w.SetColumnPadChar(0, '.')
+	w.SetLeaderWidth(width)
+	return w
+}
+
+// SetLeaderWidth joins the first and last cells of each row with leaders, so
+// that rows are width columns wide. The leaders are the padding of the first
+// column, and consist of its pad character, which is set with
+// SetColumnPadChar. They are separated from the cells by spaces, and the last
+// cells are right-aligned. Rows whose first cell is too wide for the width
+// are output with a single leader character. Leaders are meant for rows of
+// two cells and are only output by the text output format. A width of 0
+// disables leaders.
+func (w *Writer) SetLeaderWidth(width int) {
+	w.leaderWidth = width
+}
+
+// leaderLines prepares the buffered rows for the output of leaders: the
+// first column is widened to fill the rows' width, the first cells are
+// followed by a space, and the last cells are padded to a common width and
+// preceded by a space. Rows whose first cell is too wide are replaced by a
+// single cell holding their text joined by one leader character.
+func (w *Writer) leaderLines() {
+	w.leaderMin = 0
+	if w.leaderWidth <= 0 || w.flushFormat != FormatText {
+		return
+	}
+
+	last := 0
+	w.forEachLeaderLine(func(l *line) {
+		last = max(last, l.cells[len(l.cells)-1].width)
+	})
+	w.leaderMin = w.leaderWidth - last - 1
+
+	padding := w.getFormat(0).padding
+	leader := w.getColumn(0).padchar
+	if leader == 0 {
+		leader = w.padchar
+	}
+	w.forEachLeaderLine(func(l *line) {
+		first, end := &l.cells[0], &l.cells[len(l.cells)-1]
+		if first.width+1+max(padding, 1) > w.leaderMin {
+			texts := w.cellTexts(*l)
+			texts[0] += " " + string(leader)
+			*l = w.newLine([]string{strings.Join(texts, " ")})
+			return
+		}
+		w.setCellText(first, string(w.cellText(*first))+" ")
+		pad := strings.Repeat(" ", last-end.width+1)
+		w.setCellText(end, pad+string(w.cellText(*end)))
+	})
+}
+
+// forEachLeaderLine calls fn for each buffered row of at least two cells.
+func (w *Writer) forEachLeaderLine(fn func(l *line)) {
+	for i := range w.lines {
+		l := &w.lines[i]
+		if l.raw.size == 0 && l.groups == nil && len(l.cells) >= 2 {
+			fn(l)
+		}
+	}
+}
+
+// setCellText replaces the text of cell c.
+func (w *Writer) setCellText(c *cell, text string) {
+	c.start, c.size = w.buf.Len(), len(text)
+	c.width = w.measure([]byte(text))
+	c.segs = nil
+	w.buf.WriteString(text)
+}
@@ -0,0 +1,25 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestNewTOCWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewTOCWriter(&b, 24)
+	fmt.Fprint(w, "Introduction\t1\n")
+	fmt.Fprint(w, "Getting started\t5\n")
+	fmt.Fprint(w, "Reference\t42\n")
+	fmt.Fprint(w, "A very long appendix title\t108\n")
+	w.Flush()
+
+	want := "Introduction .......   1\n" +
+		"Getting started ....   5\n" +
+		"Reference ..........  42\n" +
+		"A very long appendix title . 108\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,72 @@
+package tabwriter
+
+import (
+	"io"
+	"strings"
+)
+
+// defaultListWidth is the line width WriteList fills when neither the caller
+// nor the terminal provides one.
+const defaultListWidth = 80
+
+// WriteList writes a list of short items as rows of balanced columns, like
+// the multi-column output of ls. Items are ordered down each column and then
+// across, and the writer uses as many columns as fit within width columns
+// using its column format settings. If width is 0, the terminal's width is
+// used, or 80 if the terminal's width is unknown. Items must not contain
+// tabs or newlines. Like any rows, the list is aligned on the next flush.
+func (w *Writer) WriteList(items []string, width int) {
+	w.endRow()
+	if len(items) == 0 {
+		return
+	}
+
+	if width <= 0 && w.terminal != nil {
+		width = w.terminal.Width()
+	}
+	if width <= 0 {
+		width = defaultListWidth
+	}
+
+	widths := make([]int, len(items))
+	for i, item := range items {
+		widths[i] = w.measure([]byte(item))
+	}
+
+	rows := len(items)
+	for cols := len(items); cols > 1; cols-- {
+		r := (len(items) + cols - 1) / cols
+		if w.listWidth(widths, r) <= width {
+			rows = r
+			break
+		}
+	}
+
+	for r := 0; r < rows; r++ {
+		var row []string
+		for i := r; i < len(items); i += rows {
+			row = append(row, items[i])
+		}
+		io.WriteString(w, strings.Join(row, "\t")+"\n")
+	}
+}
+
+// listWidth returns the width of the widest line of a list whose items have
+// the given widths when it is laid out in columns of the given number of
+// rows.
+func (w *Writer) listWidth(widths []int, rows int) int {
+	total := 0
+	for col, i := 0, 0; i < len(widths); col, i = col+1, i+rows {
+		textwidth := MaxContent(widths[i:min(i+rows, len(widths))])
+		if i+rows >= len(widths) {
+			// The last column is not padded.
+			return total + textwidth
+		}
+		cellwidth := w.cellWidth(nil, col, textwidth)
+		if w.padchar == '\t' {
+			cellwidth = w.tabify(cellwidth)
+		}
+		total += cellwidth
+	}
+	return total
+}
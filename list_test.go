@@ -0,0 +1,34 @@
+package tabwriter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteList(t *testing.T) {
+	items := []string{"bin", "boot", "dev", "etc", "home", "lib", "media", "mnt", "opt", "proc", "root"}
+	tests := []struct {
+		width int
+		want  string
+	}{
+		{30, "" +
+			"bin  dev home media opt root\n" +
+			"boot etc lib  mnt   proc\n"},
+		{16, "" +
+			"bin  home  opt\n" +
+			"boot lib   proc\n" +
+			"dev  media root\n" +
+			"etc  mnt\n"},
+		{3, "bin\nboot\ndev\netc\nhome\nlib\nmedia\nmnt\nopt\nproc\nroot\n"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.WriteList(items, test.width)
+		w.Flush()
+		if b.String() != test.want {
+			t.Errorf("width %d: got:\n%s\nwant:\n%s", test.width, b.String(), test.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package tabwriter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// recordClean replaces the characters of loaded values that would be
+// interpreted as table structure.
+var recordClean = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ", "\f", " ")
+
+// writeRecord writes fields as a row of cells, replacing tabs and line breaks
+// within them by spaces. Like WriteRow, it adds the cells directly, so that
+// their text is not interpreted by Write. A record without fields is not
+// written.
+func (w *Writer) writeRecord(fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	w.addRow(fields, recordClean)
+}
+
+// FromCSV writes each record of the CSV text read from r as a row of the
+// writer. Records may have different numbers of fields. Tabs and line breaks
+// within fields are replaced by spaces. A partially written row is
+// terminated first. The rows are aligned on the next flush.
+func (w *Writer) FromCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	w.endRow()
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		w.writeRecord(record)
+	}
+}
+
+// FromJSON writes the JSON array of objects read from r as rows of the
+// writer, preceded by a row of the objects' keys, in the order they first
+// appear. Values are written in the column of their key; strings are written
+// without quotes, null values and missing keys as empty cells, and all other
+// values as compact JSON. A partially written row is terminated first. The
+// rows are aligned on the next flush.
+func (w *Writer) FromJSON(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	var keys []string
+	var records []map[string]string
+	for dec.More() {
+		if err := expectDelim(dec, '{'); err != nil {
+			return err
+		}
+		record := make(map[string]string)
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key := t.(string)
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			if _, ok := record[key]; !ok && indexOf(keys, key) < 0 {
+				keys = append(keys, key)
+			}
+			record[key] = jsonText(value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	w.endRow()
+	w.writeRecord(keys)
+	fields := make([]string, len(keys))
+	for _, record := range records {
+		for i, key := range keys {
+			fields[i] = record[key]
+		}
+		w.writeRecord(fields)
+	}
+	return nil
+}
+
+// expectDelim reads the next token of dec and returns an error unless it is
+// delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return errors.New("tabwriter: JSON input is not an array of objects")
+	}
+	return nil
+}
+
+// jsonText returns the cell text of a JSON value.
+func jsonText(value json.RawMessage) string {
+	var s string
+	switch {
+	case string(value) == "null":
+		return ""
+	case json.Unmarshal(value, &s) == nil:
+		return s
+	}
+	var b bytes.Buffer
+	if json.Compact(&b, value) != nil {
+		return string(value)
+	}
+	return b.String()
+}
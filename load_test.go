@@ -0,0 +1,94 @@
+package tabwriter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFromCSV(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	err := w.FromCSV(strings.NewReader("name,city\n\"Smith, J\",\"New\nYork\"\nDoe,Paris\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+
+	want := "name     city\n" +
+		"Smith, J New York\n" +
+		"Doe      Paris\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	w = NewWriter(&b, 0, 8, 1, ' ', 0)
+	if err := w.FromCSV(strings.NewReader("a,\"b\n")); err == nil {
+		t.Error("got no error for malformed CSV")
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	err := w.FromJSON(strings.NewReader(`[
+		{"name": "web", "replicas": 3, "ports": [80, 443], "id": 1},
+		{"name": "db", "ready": true, "replicas": null, "id": 2}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+
+	want := "name replicas ports    id ready\n" +
+		"web  3        [80,443] 1\n" +
+		"db                     2 true\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	for _, input := range []string{`{"a": 1}`, `[1, 2]`, `[{"a": 1}`} {
+		w = NewWriter(&b, 0, 8, 1, ' ', 0)
+		if err := w.FromJSON(strings.NewReader(input)); err == nil {
+			t.Errorf("got no error for %s", input)
+		}
+	}
+}
+
+func TestFromCSVRaw(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', FilterHTML)
+	err := w.FromCSV(strings.NewReader("a,b\n\xffx,<y\n\n&z,w\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("got output before Flush: %q", b.String())
+	}
+	w.Flush()
+
+	want := "a b\n" +
+		"\xffx <y\n" +
+		"&z w\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	b.Reset()
+	w = NewWriter(&b, 0, 8, 1, ' ', 0)
+	if err := w.FromCSV(strings.NewReader("a,b\nc\n\"\"\nd\n")); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("got output before Flush: %q", b.String())
+	}
+	w.Flush()
+
+	want = "a b\n" +
+		"c\n" +
+		"\n" +
+		"d\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
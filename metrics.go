@@ -0,0 +1,107 @@
+package tabwriter
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A MetricFamily is a named group of metrics distinguished by their labels,
+// like the metric families exposed by Prometheus.
+type MetricFamily struct {
+	Name    string
+	Metrics []Metric
+}
+
+// A Metric is a single sample of a metric family.
+type Metric struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// WriteMetrics writes a snapshot of named metric values as a table of rows
+// sorted by name, preceded by a heading row. Values are formatted according
+// to the unit suffix of their name, as described for WriteMetricFamilies.
+// A partially written row is terminated first.
+func (w *Writer) WriteMetrics(values map[string]float64) {
+	families := make([]MetricFamily, 0, len(values))
+	for name, value := range values {
+		families = append(families, MetricFamily{Name: name, Metrics: []Metric{{Value: value}}})
+	}
+	w.WriteMetricFamilies(families)
+}
+
+// WriteMetricFamilies writes a snapshot of metric families as a table of rows
+// sorted by metric, preceded by a heading row. Each metric is named by its
+// family's name followed by its labels in the Prometheus exposition format,
+// such as `http_requests_total{code="200"}`. Values are formatted according
+// to the unit suffix of their family's name, following Prometheus naming
+// conventions: "_bytes" values use binary prefixes, "_seconds" values are
+// written as durations and "_ratio" values as percentages. Other values are
+// written as plain numbers. A partially written row is terminated first.
+func (w *Writer) WriteMetricFamilies(families []MetricFamily) {
+	var rows [][]string
+	for _, f := range families {
+		for _, m := range f.Metrics {
+			rows = append(rows, []string{f.Name + metricLabels(m.Labels), metricValue(f.Name, m.Value)})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+
+	w.endRow()
+	w.writeRecord([]string{"METRIC", "VALUE"})
+	for _, row := range rows {
+		w.writeRecord(row)
+	}
+}
+
+// metricLabels returns labels in the Prometheus exposition format, sorted by
+// name, or an empty string if there are none.
+func metricLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		names[i] = name + "=" + strconv.Quote(labels[name])
+	}
+	return "{" + strings.Join(names, ",") + "}"
+}
+
+// binaryPrefixes holds the prefixes of byte values formatted by metricValue.
+var binaryPrefixes = []string{"Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+
+// metricValue formats the value of a metric according to the unit suffix of
+// its name.
+func metricValue(name string, v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	switch {
+	case strings.HasSuffix(name, "_bytes"):
+		if math.Abs(v) < 1024 {
+			return strconv.FormatFloat(v, 'f', -1, 64) + " B"
+		}
+		prefix := 0
+		for v /= 1024; math.Abs(v) >= 1024 && prefix < len(binaryPrefixes)-1; prefix++ {
+			v /= 1024
+		}
+		return strconv.FormatFloat(v, 'f', 1, 64) + " " + binaryPrefixes[prefix] + "B"
+
+	case strings.HasSuffix(name, "_seconds"):
+		if math.Abs(v) >= math.MaxInt64/float64(time.Second) {
+			return strconv.FormatFloat(v, 'f', -1, 64) + "s"
+		}
+		return time.Duration(v * float64(time.Second)).String()
+
+	case strings.HasSuffix(name, "_ratio"):
+		return strconv.FormatFloat(v*100, 'f', 1, 64) + "%"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,63 @@
+package tabwriter
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.WriteMetrics(map[string]float64{
+		"goroutines":           42,
+		"heap_alloc_bytes":     3 * 1024 * 1024 / 2,
+		"cache_hit_ratio":      0.875,
+		"gc_pause_seconds":     0.0025,
+		"uptime_seconds":       5400,
+		"requests_total":       1027,
+		"temperature_celsius":  math.NaN(),
+		"buffer_bytes":         512,
+		"archive_size_bytes":   5 * 1024 * 1024 * 1024 * 1024,
+		"queue_latency_bytes2": 1,
+	})
+	w.Flush()
+
+	want := "METRIC               VALUE\n" +
+		"archive_size_bytes   5.0 TiB\n" +
+		"buffer_bytes         512 B\n" +
+		"cache_hit_ratio      87.5%\n" +
+		"gc_pause_seconds     2.5ms\n" +
+		"goroutines           42\n" +
+		"heap_alloc_bytes     1.5 MiB\n" +
+		"queue_latency_bytes2 1\n" +
+		"requests_total       1027\n" +
+		"temperature_celsius  NaN\n" +
+		"uptime_seconds       1h30m0s\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteMetricFamilies(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.WriteMetricFamilies([]MetricFamily{
+		{Name: "http_requests_total", Metrics: []Metric{
+			{Labels: map[string]string{"method": "post", "code": "500"}, Value: 3},
+			{Labels: map[string]string{"method": "get", "code": "200"}, Value: 1027},
+		}},
+		{Name: "build_info", Metrics: []Metric{
+			{Labels: map[string]string{"version": `v1."2"`}, Value: 1},
+		}},
+	})
+	w.Flush()
+
+	want := "METRIC                                        VALUE\n" +
+		`build_info{version="v1.\"2\""}                1` + "\n" +
+		`http_requests_total{code="200",method="get"}  1027` + "\n" +
+		`http_requests_total{code="500",method="post"} 3` + "\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,326 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An OutputFormat selects how a Writer presents its aligned columns.
+type OutputFormat int
+
+const (
+	// FormatText separates columns with padding. It is the default output
+	// format.
+	FormatText OutputFormat = iota
+
+	// FormatBorder outputs columns in a grid framed by box-drawing
+	// characters, which are set by SetBorderStyle.
+	FormatBorder
+
+	// FormatMarkdown outputs columns as a markdown table. The first row of
+	// each flush becomes the table's header.
+	FormatMarkdown
+
+	// FormatHTML exports each flush as an HTML table, including the
+	// metadata attached to cells.
+	FormatHTML
+
+	// FormatJSON exports each flush as a JSON array of records, including
+	// the metadata attached to cells.
+	FormatJSON
+
+	// FormatAccessible outputs each row as a line of "name: value" pairs
+	// instead of aligning columns, which suits screen readers. Columns are
+	// named by SetHeader.
+	FormatAccessible
+
+	// FormatTSV outputs each row as its cells separated by single tabs,
+	// without padding, which suits programs parsing the output.
+	FormatTSV
+)
+
+// formatNames holds the names of the output formats, indexed by format.
+var formatNames = []string{"text", "border", "markdown", "html", "json", "accessible", "tsv"}
+
+// String returns the name of the output format, such as "markdown".
+func (f OutputFormat) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return "OutputFormat(" + strconv.Itoa(int(f)) + ")"
+	}
+	return formatNames[f]
+}
+
+// MarshalText encodes the output format as its name.
+func (f OutputFormat) MarshalText() ([]byte, error) {
+	if f < 0 || int(f) >= len(formatNames) {
+		return nil, fmt.Errorf("tabwriter: invalid output format %d", int(f))
+	}
+	return []byte(formatNames[f]), nil
+}
+
+// UnmarshalText decodes an output format from its name.
+func (f *OutputFormat) UnmarshalText(text []byte) error {
+	i := indexOf(formatNames, string(text))
+	if i < 0 {
+		return fmt.Errorf("tabwriter: unknown output format %q", text)
+	}
+	*f = OutputFormat(i)
+	return nil
+}
+
+// noFallback is the fallback format of writers without one.
+const noFallback OutputFormat = -1
+
+// currentFormat returns the output format to use for the next flush.
+func (w *Writer) currentFormat() OutputFormat {
+	if w.fallbackFormat == noFallback {
+		return w.outputFormat
+	}
+	if t, ok := w.terminal.(TTYReporter); ok && !t.IsTerminal() {
+		return w.fallbackFormat
+	}
+	return w.outputFormat
+}
+
+// grid returns true if the writer's output format arranges all of the cells
+// in a column to the same width.
+func (w *Writer) grid() bool {
+	return w.flushFormat == FormatBorder || w.flushFormat == FormatMarkdown
+}
+
+// layoutGrid sizes each column as a single block containing all of its
+// cells, so that columns line up on every row of a grid. It returns the
+// width of each column.
+func (w *Writer) layoutGrid() []int {
+	ncols := 0
+	for _, l := range w.lines {
+		ncols = max(ncols, len(l.cells))
+	}
+
+	widths := make([]int, ncols)
+	for col := range widths {
+		var block []*cell
+		for i := range w.lines {
+			if l := &w.lines[i]; col < len(l.cells) {
+				block = append(block, &l.cells[col])
+			}
+		}
+		widths[col] = w.sizeBlock(col, block, false)
+	}
+	return w.fitGroups(widths)
+}
+
+// writeGrid lays out and outputs the buffered lines as a grid.
+func (w *Writer) writeGrid() {
+	if w.flushFormat == FormatMarkdown {
+		w.escapeMarkdown()
+	}
+	widths := w.layoutGrid()
+
+	// Raw text preceding the table, such as its title, is output above it.
+	lines := w.lines
+	for len(lines) > 0 && lines[0].raw.size > 0 {
+		w.linebuf.Write(w.cellText(lines[0].raw))
+		w.emitLine(false)
+		lines = lines[1:]
+	}
+
+	border := w.flushFormat == FormatBorder
+	outer := border && w.borderRules&RuleOuter != 0
+	if outer && len(widths) > 0 {
+		// A leading group header row merges the junctions of the top rule.
+		top := widths
+		if len(lines) > 0 && lines[0].groups != nil {
+			top = w.groupWidths(lines[0].groups, widths)
+		}
+		w.writeRule(w.borderStyle.TopLeft, w.borderStyle.TopJunction, w.borderStyle.TopRight, top)
+		w.emitLine(false)
+	}
+
+	header, ruled := true, false
+	for _, l := range lines {
+		if l.raw.size > 0 {
+			w.linebuf.Write(w.cellText(l.raw))
+			w.emitLine(false)
+			continue
+		}
+		if ruled {
+			w.writeMiddleRule(widths)
+			w.emitLine(false)
+			ruled = false
+		}
+		if l.groups != nil {
+			w.writeGridGroups(l.groups, widths)
+			if header && !border {
+				// The titles form the markdown table's header row.
+				w.writeMarkdownRule(widths)
+				header = false
+			}
+			w.emitLine(false)
+			continue
+		}
+
+		w.writeGridLine(l, widths)
+		switch {
+		case header && !border:
+			w.writeMarkdownRule(widths)
+		case header && w.borderRules&(RuleHeader|RuleRows) != 0:
+			w.writeMiddleRule(widths)
+		case border && w.borderRules&RuleRows != 0:
+			// The rule is output before the next row, so that the last
+			// row is followed by the bottom rule only.
+			ruled = true
+		}
+		w.emitLine(header)
+		header = false
+
+		if l.description.size > 0 {
+			w.writeDescription(w.cellText(l.description))
+			w.emitLine(false)
+		}
+	}
+
+	if outer && len(widths) > 0 {
+		w.writeRule(w.borderStyle.BottomLeft, w.borderStyle.BottomJunction, w.borderStyle.BottomRight, widths)
+		w.emitLine(false)
+	}
+}
+
+// gridEdges returns the vertical rules output at the left edge of a grid,
+// between its columns and at its right edge. Rules omitted by the writer's
+// border rules are empty.
+func (w *Writer) gridEdges() (left, sep, right string) {
+	if w.flushFormat == FormatMarkdown {
+		return "|", "|", "|"
+	}
+	vertical := w.borderStyle.Vertical
+	if w.borderRules&RuleOuter != 0 {
+		left, right = vertical, vertical
+	}
+	if w.borderRules&RuleColumns != 0 {
+		sep = vertical
+	}
+	return left, sep, right
+}
+
+// writeGridLine outputs a line's cells separated by vertical rules. Lines
+// with fewer cells than the grid has columns are completed with empty cells.
+// Each output line is assembled from every column's segment of text on that
+// line, padded to the column's width, so the continuation lines of wrapped
+// cells repeat all of the separators.
+func (w *Writer) writeGridLine(l line, widths []int) {
+	left, sep, right := w.gridEdges()
+
+	height := 1
+	for _, c := range l.cells {
+		height = max(height, len(c.segs))
+	}
+
+	for k := 0; k < height; k++ {
+		w.linebuf.WriteString(left)
+		col := w.measure([]byte(left))
+		for j, width := range widths {
+			var text []byte
+			textwidth := 0
+			if j < len(l.cells) {
+				text, textwidth = w.cellLine(l.cells[j], k)
+			}
+
+			if j > 0 {
+				w.linebuf.WriteString(sep)
+				col += w.measure([]byte(sep))
+			}
+			if j > 0 || left != "" {
+				w.linebuf.Write(space)
+				col++
+			}
+			// Without a right edge, the last cell isn't padded.
+			term := j == len(widths)-1 && right == ""
+			w.writeCell(l.style.apply(text), width-textwidth, w.getFormat(j), term)
+			col += width
+		}
+		w.linebuf.WriteString(right)
+		if k == 0 && l.annotation != "" {
+			w.writeAnnotation(l.annotation, col+w.measure([]byte(right)))
+		}
+		w.linebuf.Write(newline)
+	}
+}
+
+// writeRule outputs a horizontal border rule spanning the grid's columns.
+// The corners and junctions of rules omitted by the writer's border rules
+// are left out.
+func (w *Writer) writeRule(left, junction, right string, widths []int) {
+	if w.borderRules&RuleOuter == 0 {
+		left, right = "", ""
+	}
+	if w.borderRules&RuleColumns == 0 {
+		junction = ""
+	}
+
+	w.linebuf.WriteString(left)
+	for j, width := range widths {
+		if j > 0 {
+			w.linebuf.WriteString(junction)
+		}
+		if j > 0 || left != "" {
+			width++
+		}
+		w.linebuf.WriteString(strings.Repeat(w.borderStyle.Horizontal, width))
+	}
+	w.linebuf.WriteString(right)
+	w.linebuf.Write(newline)
+}
+
+// writeMiddleRule outputs a horizontal border rule between two rows.
+func (w *Writer) writeMiddleRule(widths []int) {
+	w.writeRule(w.borderStyle.LeftJunction, w.borderStyle.Cross, w.borderStyle.RightJunction, widths)
+}
+
+// writeMarkdownRule outputs the delimiter row separating a markdown table's
+// header from its body. Right-aligned and centered columns are marked with
+// colons.
+func (w *Writer) writeMarkdownRule(widths []int) {
+	w.linebuf.WriteString("|")
+	for j, width := range widths {
+		rule := strings.Repeat("-", width+1)
+		switch flags := w.getFormat(j).flags; {
+		case flags&AlignCenter != 0 && width > 0:
+			rule = ":" + rule[:width-1] + ":"
+		case flags&AlignRight != 0:
+			rule = rule[:width] + ":"
+		}
+		w.linebuf.WriteString(rule)
+		w.linebuf.WriteString("|")
+	}
+	w.linebuf.Write(newline)
+}
+
+// escapeMarkdown escapes the pipe characters in each cell's text, which would
+// otherwise end the cell in a markdown table.
+func (w *Writer) escapeMarkdown() {
+	pipe, escaped := []byte{'|'}, []byte(`\|`)
+	for i := range w.lines {
+		for j := range w.lines[i].cells {
+			c := &w.lines[i].cells[j]
+			text := w.cellText(*c)
+			if bytes.IndexByte(text, '|') < 0 {
+				continue
+			}
+
+			text = bytes.Replace(text, pipe, escaped, -1)
+			c.start, c.size = w.buf.Len(), len(text)
+			c.width = w.measure(text)
+			c.segs = nil
+			w.buf.Write(text)
+			wrap := w.getColumn(j).wrap
+			if bytes.IndexByte(text, '\n') >= 0 {
+				w.splitCell(c, wrap)
+			} else if wrap.width > 0 && c.width > wrap.width && wrap.policy != BreakNone {
+				w.wrapCell(c, wrap.width, wrap.policy)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestFormatBorder(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, AlignRight)
+	w.SetOutputFormat(FormatBorder)
+	fmt.Fprint(w, "NAME\tSIZE\tMODIFIED\n")
+	fmt.Fprint(w, "go.mod\t94\tyesterday\n")
+	fmt.Fprint(w, "tabwriter.go\t11947\n")
+	w.Flush()
+
+	want := "┌──────────────┬───────┬───────────┐\n" +
+		"│ NAME         │  SIZE │ MODIFIED  │\n" +
+		"│ go.mod       │    94 │ yesterday │\n" +
+		"│ tabwriter.go │ 11947 │           │\n" +
+		"└──────────────┴───────┴───────────┘\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSetBorderStyle(t *testing.T) {
+	tests := []struct {
+		style BorderStyle
+		want  string
+	}{
+		{BorderASCII, "" +
+			"+------+----+\n" +
+			"| NAME | ID |\n" +
+			"| a.go | 7  |\n" +
+			"+------+----+\n"},
+		{BorderRounded, "" +
+			"╭──────┬────╮\n" +
+			"│ NAME │ ID │\n" +
+			"│ a.go │ 7  │\n" +
+			"╰──────┴────╯\n"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.SetOutputFormat(FormatBorder)
+		w.SetBorderStyle(test.style)
+		fmt.Fprint(w, "NAME\tID\n")
+		fmt.Fprint(w, "a.go\t7\n")
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("got:\n%s\nwant:\n%s", b.String(), test.want)
+		}
+	}
+}
+
+func TestSetBorderRules(t *testing.T) {
+	tests := []struct {
+		rules Rules
+		want  string
+	}{
+		{RuleAll, "" +
+			"┌──────┬──────┐\n" +
+			"│ NAME │ SIZE │\n" +
+			"├──────┼──────┤\n" +
+			"│ a.go │   12 │\n" +
+			"├──────┼──────┤\n" +
+			"│ b.go │    4 │\n" +
+			"└──────┴──────┘\n"},
+		{RuleOuter | RuleHeader, "" +
+			"┌────────────┐\n" +
+			"│ NAME  SIZE │\n" +
+			"├────────────┤\n" +
+			"│ a.go    12 │\n" +
+			"│ b.go     4 │\n" +
+			"└────────────┘\n"},
+		{RuleHeader, "" +
+			"NAME  SIZE\n" +
+			"───────────\n" +
+			"a.go    12\n" +
+			"b.go     4\n"},
+		{RuleColumns, "" +
+			"NAME │ SIZE\n" +
+			"a.go │   12\n" +
+			"b.go │    4\n"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.SetColumnFormat(1, 0, 1, AlignRight)
+		w.SetOutputFormat(FormatBorder)
+		w.SetBorderRules(test.rules)
+		fmt.Fprint(w, "NAME\tSIZE\n")
+		fmt.Fprint(w, "a.go\t12\n")
+		fmt.Fprint(w, "b.go\t4\n")
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("rules %d: got:\n%s\nwant:\n%s", test.rules, b.String(), test.want)
+		}
+	}
+}
+
+func TestFormatMarkdown(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, AlignRight)
+	w.SetOutputFormat(FormatMarkdown)
+	fmt.Fprint(w, "Operator\tPrecedence\n")
+	fmt.Fprint(w, "||\t1\n")
+	fmt.Fprint(w, "*\t5\n")
+	w.Flush()
+
+	want := "| Operator | Precedence |\n" +
+		"|----------|-----------:|\n" +
+		"| \\|\\|     |          1 |\n" +
+		"| *        |          5 |\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+// pipe is a Terminal describing output that is not a TTY.
+type pipe struct{}
+
+func (pipe) Width() int       { return 0 }
+func (pipe) IsTerminal() bool { return false }
+
+func TestFallbackFormat(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetOutputFormat(FormatBorder)
+	w.SetFallbackFormat(FormatTSV)
+	w.SetTerminal(pipe{})
+	fmt.Fprint(w, "NAME\tSIZE\n")
+	w.WriteRaw([]byte("# sources\n"))
+	fmt.Fprint(w, "go.mod\t94\n")
+	w.Flush()
+
+	want := "NAME\tSIZE\n" +
+		"go.mod\t94\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	b.Reset()
+	w.SetTerminal(FixedWidth(80))
+	fmt.Fprint(w, "a\tb\n")
+	w.Flush()
+	want = "┌───┬───┐\n" +
+		"│ a │ b │\n" +
+		"└───┴───┘\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
@@ -0,0 +1,160 @@
+package tabwriter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Renderer transforms the text of a cell before it is measured and output.
+// Renderers are assigned to columns with SetColumnRenderer.
+type Renderer func(text string) string
+
+// sparks holds the block characters used by Sparkline, from lowest to
+// highest.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline returns a renderer that converts a comma-separated series of
+// numbers into a unicode sparkline. At most width values are rendered; if the
+// series is longer, only its last width values are used. Cells that do not
+// contain a numeric series are output unchanged, as are all cells if width is
+// not positive.
+func Sparkline(width int) Renderer {
+	return func(text string) string {
+		if width <= 0 {
+			return text
+		}
+		fields := strings.Split(text, ",")
+		if len(fields) > width {
+			fields = fields[len(fields)-width:]
+		}
+
+		values := make([]float64, len(fields))
+		for i, f := range fields {
+			v, ok := parseNumber(f)
+			if !ok || math.IsInf(v, 0) {
+				return text
+			}
+			values[i] = v
+		}
+		if len(values) == 0 {
+			return text
+		}
+
+		lo, hi := values[0], values[0]
+		for _, v := range values[1:] {
+			if v < lo {
+				lo = v
+			}
+			if v > hi {
+				hi = v
+			}
+		}
+
+		line := make([]rune, len(values))
+		for i, v := range values {
+			level := 0
+			if hi > lo {
+				// Halving the values keeps their range from overflowing.
+				level = int((v/2-lo/2)/(hi/2-lo/2)*float64(len(sparks)-1) + 0.5)
+			}
+			line[i] = sparks[level]
+		}
+		return string(line)
+	}
+}
+
+// bars holds the block characters used by Bar to draw the fractional end of
+// a bar, from one eighth to a full block.
+var bars = []rune("▏▎▍▌▋▊▉█")
+
+// Bar returns a renderer that converts a number into a horizontal bar whose
+// length is proportional to the number, drawn with eighth-block characters. A
+// number equal to full fills width columns; larger numbers are clipped. Cells
+// that do not contain a number, and negative numbers, are output unchanged.
+func Bar(width int, full float64) Renderer {
+	return func(text string) string {
+		v, ok := parseNumber(text)
+		if !ok || v < 0 || full <= 0 {
+			return text
+		}
+
+		eighths := int(math.Min(v/full, 1)*float64(width*8) + 0.5)
+		bar := strings.Repeat(string(bars[len(bars)-1]), eighths/8)
+		if eighths%8 > 0 {
+			bar += string(bars[eighths%8-1])
+		}
+		return bar
+	}
+}
+
+// boolValues maps the recognized spellings of boolean values, in lower case,
+// to the values they represent.
+var boolValues = map[string]bool{
+	"true": true, "t": true, "yes": true, "y": true, "on": true, "1": true,
+	"false": false, "f": false, "no": false, "n": false, "off": false, "0": false,
+}
+
+// Bool returns a renderer that replaces boolean cell values with the symbols
+// yes and no (for instance "✓" and "✗"). Values are recognized without regard
+// to case or surrounding space and may be spelled true/false, t/f, yes/no,
+// y/n, on/off or 1/0. The shorter symbol is padded with spaces to the width
+// of the longer one so that both occupy the same space in a column. Cells
+// that do not contain a recognized value are output unchanged.
+func Bool(yes, no string) Renderer {
+	yw, nw := displayWidth([]byte(yes)), displayWidth([]byte(no))
+	if yw < nw {
+		yes += strings.Repeat(" ", nw-yw)
+	} else {
+		no += strings.Repeat(" ", yw-nw)
+	}
+
+	return func(text string) string {
+		v, ok := boolValues[strings.ToLower(strings.TrimSpace(text))]
+		switch {
+		case !ok:
+			return text
+		case v:
+			return yes
+		default:
+			return no
+		}
+	}
+}
+
+// Mask returns a renderer that replaces all but the last keep characters of
+// a cell with asterisks, which suits redacting secrets such as tokens and
+// card numbers with SetColumnRedactor. Empty cells are output unchanged. A
+// negative keep is treated as 0.
+func Mask(keep int) Renderer {
+	keep = max(keep, 0)
+	return func(text string) string {
+		runes := []rune(text)
+		n := max(len(runes)-keep, 0)
+		return strings.Repeat("*", n) + string(runes[n:])
+	}
+}
+
+// HexEscape is a renderer that replaces each byte of text that is not part of
+// a valid UTF-8 sequence with a hex escape such as \xff, so that binary data
+// like file names and registry keys is output legibly and measured correctly.
+// Use it with SetColumnRenderer for a single column, or with SetNormalizer
+// for every column.
+func HexEscape(text string) string {
+	if utf8.ValidString(text) {
+		return text
+	}
+
+	var b strings.Builder
+	for p := 0; p < len(text); {
+		r, size := utf8.DecodeRuneInString(text[p:])
+		if r == utf8.RuneError && size == 1 {
+			b.WriteString(`\x` + strconv.FormatUint(uint64(text[p]), 16))
+		} else {
+			b.WriteString(text[p : p+size])
+		}
+		p += size
+	}
+	return b.String()
+}
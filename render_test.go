@@ -0,0 +1,150 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		width int
+		in    string
+		out   string
+	}{
+		{8, "1,2,3,4,5,6,7,8", "▁▂▃▄▅▆▇█"},
+		{4, "1,2,3,4,5,6,7,8", "▁▃▆█"},
+		{8, "5, 5, 5", "▁▁▁"},
+		{8, "0,10,5", "▁█▅"},
+		{8, "n/a", "n/a"},
+		{8, "", ""},
+		{8, "1,2,NaN", "1,2,NaN"},
+		{8, "1,Inf,2", "1,Inf,2"},
+		{8, "-1e308,1e308", "▁█"},
+		{0, "1,2,3", "1,2,3"},
+		{-1, "1,2,3", "1,2,3"},
+	}
+
+	for _, test := range tests {
+		out := Sparkline(test.width)(test.in)
+		if out != test.out {
+			t.Errorf("Sparkline(%d)(%q) = %q, want %q", test.width, test.in, out, test.out)
+		}
+	}
+}
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"10", "████"},
+		{"5", "██"},
+		{"1", "▍"},
+		{"20", "████"},
+		{"0", ""},
+		{"-3", "-3"},
+		{"n/a", "n/a"},
+	}
+
+	for _, test := range tests {
+		if out := Bar(4, 10)(test.in); out != test.out {
+			t.Errorf("Bar(4, 10)(%q) = %q, want %q", test.in, out, test.out)
+		}
+	}
+}
+
+func TestBool(t *testing.T) {
+	tests := []struct {
+		yes, no string
+		in      string
+		out     string
+	}{
+		{"✓", "✗", "true", "✓"},
+		{"✓", "✗", " FALSE ", "✗"},
+		{"✓", "✗", "Y", "✓"},
+		{"✓", "✗", "0", "✗"},
+		{"yes", "no", "on", "yes"},
+		{"yes", "no", "off", "no "},
+		{"y", "no", "t", "y "},
+		{"✓", "✗", "maybe", "maybe"},
+	}
+
+	for _, test := range tests {
+		out := Bool(test.yes, test.no)(test.in)
+		if out != test.out {
+			t.Errorf("Bool(%q, %q)(%q) = %q, want %q", test.yes, test.no, test.in, out, test.out)
+		}
+	}
+}
+
+func TestHexEscape(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"plain", "plain"},
+		{"caf\u00e9", "caf\u00e9"},
+		{"bad\xff\xfename", `bad\xff\xfename`},
+		{"trunc\xe2\x82", `trunc\xe2\x82`},
+		{"\x80", `\x80`},
+	}
+
+	for _, test := range tests {
+		if out := HexEscape(test.in); out != test.out {
+			t.Errorf("HexEscape(%q) = %q, want %q", test.in, out, test.out)
+		}
+	}
+}
+
+func TestColumnRenderer(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnRenderer(1, Sparkline(4))
+	w.Write([]byte("api\t1,2,3,4\tok\n"))
+	w.Write([]byte("database\t4,3,2,1\tok\n"))
+	w.Flush()
+
+	want := "api      ▁▃▆█ ok\n" +
+		"database █▆▃▁ ok\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestColumnRedactor(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnRedactor(1, Mask(4))
+	w.SetRedactionNote(" (redacted)")
+	fmt.Fprint(w, "alice\tsk-12345678\tok\n")
+	fmt.Fprint(w, "bob\t42\tok\n")
+	w.Flush()
+
+	want := "alice *******5678 (redacted) ok\n" +
+		"bob   42                     ok\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		keep int
+		in   string
+		out  string
+	}{
+		{4, "sk-12345678", "*******5678"},
+		{4, "42", "42"},
+		{0, "abc", "***"},
+		{-2, "abc", "***"},
+		{4, "", ""},
+	}
+
+	for _, test := range tests {
+		out := Mask(test.keep)(test.in)
+		if out != test.out {
+			t.Errorf("Mask(%d)(%q) = %q, want %q", test.keep, test.in, out, test.out)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package tabwriter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A ColumnType is the kind of value detected in a column by InferSchema.
+type ColumnType int
+
+const (
+	// TypeEmpty is the type of a column containing no values.
+	TypeEmpty ColumnType = iota
+
+	// TypeInteger is the type of a column containing only integers.
+	TypeInteger
+
+	// TypeFloat is the type of a column containing only numbers, at least
+	// one of which is not an integer.
+	TypeFloat
+
+	// TypeText is the type of a column containing values other than
+	// numbers.
+	TypeText
+)
+
+var columnTypeNames = []string{"empty", "integer", "float", "text"}
+
+// String returns the name of the column type.
+func (t ColumnType) String() string {
+	if t < 0 || int(t) >= len(columnTypeNames) {
+		return "ColumnType(" + strconv.Itoa(int(t)) + ")"
+	}
+	return columnTypeNames[t]
+}
+
+// ColumnSchema describes the values buffered in a column.
+type ColumnSchema struct {
+	Type     ColumnType // most specific type matching all of the values
+	Values   int        // number of non-empty cells
+	Numeric  int        // number of cells containing numbers
+	Nulls    int        // number of empty cells
+	MaxWidth int        // width of the widest cell's text
+	Heading  bool       // whether the first row's value is a heading
+}
+
+// InferSchema reports the type, maximum width and number of empty cells of
+// each column of the rows buffered since the last flush. Numbers may contain
+// thousands separators and end with a percent sign. A text value in the first
+// row is assumed to be a heading, and does not affect the column's type. If
+// UseFirstRowAsHeader is enabled, every value in the first row is a heading.
+func (w *Writer) InferSchema() []ColumnSchema {
+	var schema []ColumnSchema
+	first := true
+	for _, l := range w.lines {
+		if l.raw.size > 0 || l.groups != nil || len(l.cells) == 0 {
+			continue
+		}
+
+		for j, c := range l.cells {
+			text := strings.TrimSpace(string(w.cellText(c)))
+			if text == "" && j == len(l.cells)-1 && j > 0 {
+				// Ignore the empty cell following a trailing tab.
+				continue
+			}
+			for len(schema) <= j {
+				schema = append(schema, ColumnSchema{})
+			}
+
+			s := &schema[j]
+			s.MaxWidth = max(s.MaxWidth, c.width)
+			if text == "" {
+				s.Nulls++
+				continue
+			}
+
+			s.Values++
+			if first && w.firstRowHeader {
+				s.Heading = true
+				continue
+			}
+			t := valueType(text)
+			if t != TypeText {
+				s.Numeric++
+			} else if first {
+				s.Heading = true
+				continue
+			}
+			if t > s.Type {
+				s.Type = t
+			}
+		}
+		first = false
+	}
+
+	// Headings only determine the type of columns with no other values.
+	for j := range schema {
+		if schema[j].Heading && schema[j].Type == TypeEmpty {
+			schema[j].Type = TypeText
+		}
+	}
+	return schema
+}
+
+// AutoAlign enables or disables automatic alignment. When enabled, each
+// flush right-aligns the columns whose values are predominantly numbers,
+// leaving text columns aligned left. Headings are not counted when deciding
+// whether a column is numeric. Columns already set to align right are not
+// affected.
+func (w *Writer) AutoAlign(enabled bool) {
+	w.autoAlign = enabled
+}
+
+// alignColumns determines which columns of the buffered rows are right-
+// aligned by automatic alignment.
+func (w *Writer) alignColumns() {
+	w.alignRight = nil
+	if !w.autoAlign {
+		return
+	}
+	schema := w.InferSchema()
+	w.alignRight = make([]bool, len(schema))
+	for col, s := range schema {
+		values := s.Values
+		if s.Heading {
+			values--
+		}
+		w.alignRight[col] = s.Numeric > 0 && 2*s.Numeric > values
+	}
+}
+
+// valueType returns the type of a non-empty value.
+func valueType(text string) ColumnType {
+	if _, ok := parseNumber(text); !ok {
+		return TypeText
+	}
+	if _, err := strconv.ParseInt(numberText(text), 10, 64); err == nil {
+		return TypeInteger
+	}
+	return TypeFloat
+}
+
+// parseNumber parses text as a number, which may contain thousands
+// separators and end with a percent sign.
+func parseNumber(text string) (float64, bool) {
+	text = numberText(text)
+
+	// Reject the names of special values accepted by ParseFloat, such as
+	// "Inf" and "NaN".
+	digits := strings.TrimLeft(text, "+-")
+	if digits == "" || (digits[0] != '.' && (digits[0] < '0' || digits[0] > '9')) {
+		return 0, false
+	}
+
+	n, err := strconv.ParseFloat(text, 64)
+	return n, err == nil
+}
+
+// numberText removes the thousands separators and percent sign from text.
+func numberText(text string) string {
+	text = strings.TrimSuffix(strings.TrimSpace(text), "%")
+	return strings.Replace(text, ",", "", -1)
+}
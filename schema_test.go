@@ -0,0 +1,70 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestInferSchema(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	fmt.Fprint(w, "name\tsize\tratio\tnote\n")
+	fmt.Fprint(w, "alpha\t1,024\t0.5\t\n")
+	fmt.Fprint(w, "beta\t\t12%\tInf\n")
+	fmt.Fprint(w, "gamma\t7\t3\tok\n")
+
+	want := []ColumnSchema{
+		{Type: TypeText, Values: 4, Numeric: 0, Nulls: 0, MaxWidth: 5, Heading: true},
+		{Type: TypeInteger, Values: 3, Numeric: 2, Nulls: 1, MaxWidth: 5, Heading: true},
+		{Type: TypeFloat, Values: 4, Numeric: 3, Nulls: 0, MaxWidth: 5, Heading: true},
+		{Type: TypeText, Values: 3, Numeric: 0, Nulls: 0, MaxWidth: 4, Heading: true},
+	}
+	if got := w.InferSchema(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	w.AutoAlign(true)
+	w.Flush()
+	want2 := "" +
+		"name   size ratio note\n" +
+		"alpha 1,024   0.5\n" +
+		"beta        12% Inf\n" +
+		"gamma     7   3 ok\n"
+	if b.String() != want2 {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want2)
+	}
+}
+
+func TestAutoAlign(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.AutoAlign(true)
+	fmt.Fprint(w, "host\tload\tversion\t\n")
+	fmt.Fprint(w, "alpha\t0.75\t1.2\t\n")
+	fmt.Fprint(w, "beta\tn/a\tv2\t\n")
+	fmt.Fprint(w, "gamma\t12.5\tv3\t\n")
+	w.Flush()
+
+	want := "" +
+		"host  load version\n" +
+		"alpha 0.75 1.2\n" +
+		"beta   n/a v2\n" +
+		"gamma 12.5 v3\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	// Alignment is decided anew by each flush.
+	b.Reset()
+	fmt.Fprint(w, "host\tload\t\n")
+	fmt.Fprint(w, "alpha\thigh\t\n")
+	w.Flush()
+	want = "" +
+		"host  load\n" +
+		"alpha high\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
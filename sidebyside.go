@@ -0,0 +1,95 @@
+package tabwriter
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// SideBySide flushes two writers and writes their output to dst side by
+// side, separated by gutter, so that tables formatted independently can be
+// compared line by line. Each of left's output lines is padded to the width
+// of its widest line. If width is positive and the combined lines would be
+// wider, the lines of the wider side are truncated with its writer's
+// ellipsis, so that each side is given at least half of the width left by
+// the gutter. Wrapping cells instead, for example with SetColumnWrap, keeps
+// their text visible. SideBySide returns the first error encountered while
+// writing to dst.
+func SideBySide(dst io.Writer, left, right *Writer, gutter string, width int) error {
+	leftLines, leftWidth := left.renderLines()
+	rightLines, rightWidth := right.renderLines()
+
+	gutterWidth := displayWidth([]byte(gutter))
+	if width > 0 && leftWidth+gutterWidth+rightWidth > width {
+		avail := max(width-gutterWidth, 2)
+		half := avail / 2
+		switch {
+		case leftWidth <= half:
+			rightWidth = avail - leftWidth
+		case rightWidth <= avail-half:
+			leftWidth = avail - rightWidth
+		default:
+			leftWidth, rightWidth = half, avail-half
+		}
+		for i, l := range leftLines {
+			leftLines[i] = left.truncateLine(l, leftWidth)
+		}
+		for i, l := range rightLines {
+			rightLines[i] = right.truncateLine(l, rightWidth)
+		}
+	}
+
+	var out bytes.Buffer
+	for i := 0; i < max(len(leftLines), len(rightLines)); i++ {
+		var l, r string
+		if i < len(leftLines) {
+			l = leftLines[i]
+		}
+		if i < len(rightLines) {
+			r = rightLines[i]
+		}
+
+		line := l + strings.Repeat(" ", max(leftWidth-left.measure([]byte(l)), 0)) + gutter + r
+		if r == "" {
+			line = strings.TrimRight(line, " ")
+		}
+		out.WriteString(line)
+		out.Write(newline)
+	}
+	_, err := dst.Write(out.Bytes())
+	return err
+}
+
+// renderLines flushes the writer and returns its output lines, along with
+// the width of the widest line.
+func (w *Writer) renderLines() ([]string, int) {
+	var b bytes.Buffer
+	output := w.output
+	w.output = &b
+	w.Flush()
+	w.output = output
+
+	text := strings.TrimSuffix(b.String(), "\n")
+	if text == "" {
+		return nil, 0
+	}
+	lines := strings.Split(text, "\n")
+	width := 0
+	for _, l := range lines {
+		width = max(width, w.measure([]byte(l)))
+	}
+	return lines, width
+}
+
+// truncateLine shortens an output line to width columns, replacing its end
+// with the writer's ellipsis. If the ellipsis itself is wider than width, it
+// is shortened instead.
+func (w *Writer) truncateLine(line string, width int) string {
+	if w.measure([]byte(line)) <= width {
+		return line
+	}
+	if width < w.ellipsisWidth() {
+		return string(cutText([]byte(w.ellipsis), width, w.measure))
+	}
+	return string(cutText([]byte(line), width-w.ellipsisWidth(), w.measure)) + w.ellipsis
+}
@@ -0,0 +1,57 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestSideBySide(t *testing.T) {
+	before := NewWriter(nil, 0, 8, 1, ' ', 0)
+	fmt.Fprint(before, "NAME\tREPLICAS\n")
+	fmt.Fprint(before, "api\t3\n")
+	fmt.Fprint(before, "worker\t4\n")
+
+	after := NewWriter(nil, 0, 8, 1, ' ', 0)
+	fmt.Fprint(after, "NAME\tREPLICAS\tIMAGE\n")
+	fmt.Fprint(after, "api\t5\tapi:v2\n")
+
+	var b bytes.Buffer
+	if err := SideBySide(&b, before, after, " | ", 0); err != nil {
+		t.Fatal(err)
+	}
+	want := "NAME   REPLICAS | NAME REPLICAS IMAGE\n" +
+		"api    3        | api  5        api:v2\n" +
+		"worker 4        |\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	fmt.Fprint(before, "a\tbbbbbbbbbbbbbbbbbbbbbbbb\n")
+	fmt.Fprint(after, "a\tbb\n")
+	b.Reset()
+	if err := SideBySide(&b, before, after, " | ", 20); err != nil {
+		t.Fatal(err)
+	}
+	want = "a bbbbbbbbbb… | a bb\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSideBySideNarrow(t *testing.T) {
+	left := NewWriter(nil, 0, 8, 1, ' ', 0)
+	left.SetEllipsis("...")
+	fmt.Fprint(left, "abc\tdef\n")
+	right := NewWriter(nil, 0, 8, 1, ' ', 0)
+	right.SetEllipsis("...")
+	fmt.Fprint(right, "ghi\tjkl\n")
+
+	var b bytes.Buffer
+	if err := SideBySide(&b, left, right, " | ", 5); err != nil {
+		t.Fatal(err)
+	}
+	if want := ". | ."; b.String() != want+"\n" {
+		t.Errorf("got %q, want %q", b.String(), want+"\n")
+	}
+}
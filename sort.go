@@ -0,0 +1,377 @@
+package tabwriter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A Comparator compares the text of two cells for sorting. It returns a
+// negative number if a sorts before b, a positive number if a sorts after b,
+// and 0 if they are equal.
+type Comparator func(a, b string) int
+
+// Lexical compares cells byte-wise. It is the default comparator.
+func Lexical(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// Natural compares cells so that runs of digits are ordered by their numeric
+// value, which sorts "file2" before "file10". All other text is compared
+// byte-wise.
+func Natural(a, b string) int {
+	for a != "" && b != "" {
+		da, db := isDigit(a[0]), isDigit(b[0])
+		if da != db {
+			return strings.Compare(a[:1], b[:1])
+		}
+
+		// Compare the leading runs of digits or of other characters.
+		var ra, rb string
+		ra, a = splitRun(a, da)
+		rb, b = splitRun(b, db)
+		if da {
+			if c := compareDigits(ra, rb); c != 0 {
+				return c
+			}
+		} else if c := strings.Compare(ra, rb); c != 0 {
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
+
+// Version compares cells holding version numbers, such as "1.10.0" and
+// "v2.0.0-rc.1", by semantic versioning precedence: "1.10.0" sorts after
+// "1.9.0", and pre-releases sort before the release they precede. A leading
+// "v" and build metadata following '+' are ignored, and missing components
+// count as 0. Cells that are not versions are compared using Natural.
+func Version(a, b string) int {
+	va, oka := parseVersion(a)
+	vb, okb := parseVersion(b)
+	if !oka || !okb {
+		return Natural(a, b)
+	}
+
+	for i := 0; i < len(va.core) || i < len(vb.core); i++ {
+		var ca, cb string
+		if i < len(va.core) {
+			ca = va.core[i]
+		}
+		if i < len(vb.core) {
+			cb = vb.core[i]
+		}
+		if c := compareDigits(strings.TrimLeft(ca, "0"), strings.TrimLeft(cb, "0")); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case va.pre == nil && vb.pre == nil:
+		return 0
+	case va.pre == nil:
+		return 1
+	case vb.pre == nil:
+		return -1
+	}
+	for i := 0; i < len(va.pre) && i < len(vb.pre); i++ {
+		if c := comparePrerelease(va.pre[i], vb.pre[i]); c != 0 {
+			return c
+		}
+	}
+	return len(va.pre) - len(vb.pre)
+}
+
+// version holds the components of a parsed version number.
+type version struct {
+	core []string // numeric components
+	pre  []string // pre-release identifiers (nil = release)
+}
+
+// parseVersion parses s as a version number.
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+
+	var v version
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.pre = strings.Split(s[i+1:], ".")
+		s = s[:i]
+	}
+	v.core = strings.Split(s, ".")
+	for _, c := range v.core {
+		if c == "" || strings.TrimLeft(c, "0123456789") != "" {
+			return version{}, false
+		}
+	}
+	return v, true
+}
+
+// comparePrerelease compares two pre-release identifiers. Numeric
+// identifiers are compared by value and sort before alphanumeric ones.
+func comparePrerelease(a, b string) int {
+	na := a != "" && strings.TrimLeft(a, "0123456789") == ""
+	nb := b != "" && strings.TrimLeft(b, "0123456789") == ""
+	switch {
+	case na && nb:
+		return compareDigits(strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0"))
+	case na:
+		return -1
+	case nb:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// IgnoreCase returns a comparator that compares cells using cmp after
+// converting them to lower case. IgnoreCase(Lexical) is a case-insensitive
+// comparator, and IgnoreCase(Natural) a case-insensitive natural one.
+func IgnoreCase(cmp Comparator) Comparator {
+	return func(a, b string) int {
+		return cmp(strings.ToLower(a), strings.ToLower(b))
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// splitRun splits s after its leading run of digits if digits is true, or
+// after its leading run of other characters if digits is false.
+func splitRun(s string, digits bool) (string, string) {
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// compareDigits compares two runs of digits by their numeric value. Runs of
+// equal value are ordered by their number of leading zeros.
+func compareDigits(a, b string) int {
+	ta, tb := strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(ta) != len(tb) {
+		return len(ta) - len(tb)
+	}
+	if c := strings.Compare(ta, tb); c != 0 {
+		return c
+	}
+	return len(a) - len(b)
+}
+
+// A Collator compares strings according to the conventions of a language.
+// It is satisfied by *collate.Collator from golang.org/x/text/collate.
+type Collator interface {
+	CompareString(a, b string) int
+}
+
+// SetColumnCollator sets the collator used to compare the text of column
+// 'col's cells when rows are sorted by a key without a comparator, so that
+// text in other languages sorts the way its readers expect. A nil collator
+// restores the default byte-wise order.
+func (w *Writer) SetColumnCollator(col int, c Collator) {
+	w.setColumn(col).collator = c
+}
+
+// A SortKey describes one of the keys rows are sorted by.
+type SortKey struct {
+	Col     int        // column whose cells are compared
+	Desc    bool       // sort in descending order
+	Numeric bool       // compare cells by numeric value
+	Compare Comparator // compares cells (nil = the column's collator or Lexical); unused if Numeric
+}
+
+// SortKeys sorts the rows of each flush by the given keys. Rows are ordered
+// by the first key, rows with equal cells for the first key by the second,
+// and so on. Numeric keys order numbers by value, and sort cells that are not
+// numbers after all numbers in either direction. Rows without a cell in a
+// key's column sort as if the cell were empty. The sort is stable, so rows
+// that are equal for every key keep the order they were written in. Raw text
+// and group headers are not moved; the rows between them are sorted
+// separately. Keys with a negative column are ignored. Passing no keys
+// disables sorting.
+func (w *Writer) SortKeys(keys []SortKey) {
+	w.sorting = nil
+	for _, key := range keys {
+		if key.Col >= 0 {
+			w.sorting = append(w.sorting, key)
+		}
+	}
+}
+
+// SortBy sorts the rows of each flush by the text of their cells in column
+// 'col', compared using cmp. It is shorthand for SortKeys with a single key.
+// A nil comparator disables sorting.
+func (w *Writer) SortBy(col int, cmp Comparator) {
+	if cmp == nil {
+		w.SortKeys(nil)
+		return
+	}
+	w.SortKeys([]SortKey{{Col: col, Compare: cmp}})
+}
+
+// Numeric compares cells by their numeric value. Numbers may contain
+// thousands separators and end with a percent sign. Cells that are not
+// numbers sort after all numbers and are compared using Natural.
+func Numeric(a, b string) int {
+	na, oka := parseNumber(a)
+	nb, okb := parseNumber(b)
+	switch {
+	case oka && okb:
+		if na < nb {
+			return -1
+		}
+		if na > nb {
+			return 1
+		}
+		return 0
+	case oka:
+		return -1
+	case okb:
+		return 1
+	}
+	return Natural(a, b)
+}
+
+// sortLines sorts the buffered lines according to the writer's sort keys.
+func (w *Writer) sortLines() {
+	if len(w.sorting) == 0 {
+		return
+	}
+
+	for start := 0; start < len(w.lines); {
+		end := start
+		for end < len(w.lines) && w.lines[end].raw.size == 0 && w.lines[end].groups == nil {
+			end++
+		}
+
+		rows := w.lines[start:end]
+		sort.SliceStable(rows, func(i, j int) bool {
+			return w.compareLines(rows[i], rows[j]) < 0
+		})
+		start = end + 1
+	}
+}
+
+// compareLines compares two lines by the writer's sort keys.
+func (w *Writer) compareLines(a, b line) int {
+	for _, key := range w.sorting {
+		cmp := key.Compare
+		switch {
+		case key.Numeric:
+			cmp = Numeric
+		case cmp != nil:
+		case w.getColumn(key.Col).collator != nil:
+			cmp = w.getColumn(key.Col).collator.CompareString
+		default:
+			cmp = Lexical
+		}
+
+		ta, tb := w.sortText(a, key.Col), w.sortText(b, key.Col)
+		c := cmp(ta, tb)
+		if key.Desc && !(key.Numeric && isNumber(ta) != isNumber(tb)) {
+			// Cells that are not numbers sort last in either order.
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// isNumber returns true if text is a number.
+func isNumber(text string) bool {
+	_, ok := parseNumber(text)
+	return ok
+}
+
+// sortText returns the text of line l's cell in column col.
+func (w *Writer) sortText(l line, col int) string {
+	if col < len(l.cells) {
+		return string(w.cellText(l.cells[col]))
+	}
+	return ""
+}
+
+// limit describes how many rows of each flush are output.
+type limit struct {
+	rows      int  // maximum number of rows output
+	remainder bool // output a row counting the omitted rows
+}
+
+// LimitRows limits the output of each flush to its first n rows, after the
+// rows have been sorted. If withRemainder is true and rows were omitted, a
+// final row reading "… and K more" reports the number of omitted rows. Raw
+// text and group headers following the last row output are omitted as well.
+// A negative n disables the limit.
+func (w *Writer) LimitRows(n int, withRemainder bool) {
+	w.limit = limit{n, withRemainder}
+}
+
+// limitLines drops the buffered lines beyond the writer's row limit.
+func (w *Writer) limitLines() {
+	if w.limit.rows < 0 {
+		return
+	}
+
+	rows, end := 0, len(w.lines)
+	for i, l := range w.lines {
+		if l.raw.size > 0 || l.groups != nil {
+			continue
+		}
+		if rows == w.limit.rows {
+			end = i
+			break
+		}
+		rows++
+	}
+
+	omitted := 0
+	for _, l := range w.lines[end:] {
+		if l.raw.size == 0 && l.groups == nil {
+			omitted++
+		}
+	}
+	w.lines = w.lines[:end]
+
+	if w.limit.remainder && omitted > 0 {
+		text := fmt.Sprintf("%s and %d more", w.ellipsis, omitted)
+		w.lines = append(w.lines, w.newLine([]string{text}))
+	}
+}
+
+// SetMinRows pads the output of each flush with empty rows until it has at
+// least n rows, not counting the header row, so that a table redrawn in place,
+// such as in a fixed-height pane of a dashboard, keeps its height. Padding
+// only applies to the text, border and markdown output formats. A value of 0
+// disables padding.
+func (w *Writer) SetMinRows(n int) {
+	w.minRows = n
+}
+
+// minRowLines appends empty rows to the buffered lines until they contain
+// the writer's minimum number of rows.
+func (w *Writer) minRowLines() {
+	switch {
+	case w.minRows <= 0:
+		return
+	case w.flushFormat != FormatText && !w.grid():
+		return
+	}
+
+	h, rows := w.headerIndex(), 0
+	for i, l := range w.lines {
+		if i != h && l.raw.size == 0 && l.groups == nil {
+			rows++
+		}
+	}
+	if rows < w.minRows {
+		w.tracef("flush: padded %d rows with %d empty rows", rows, w.minRows-rows)
+	}
+	for ; rows < w.minRows; rows++ {
+		w.lines = append(w.lines, w.newLine([]string{""}))
+	}
+}
@@ -0,0 +1,184 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestComparators(t *testing.T) {
+	tests := []struct {
+		cmp  Comparator
+		a, b string
+		want int
+	}{
+		{Lexical, "file10", "file2", -1},
+		{Natural, "file10", "file2", 1},
+		{Natural, "file2", "file2a", -1},
+		{Natural, "a01", "a1", 1},
+		{Natural, "x9y", "x9z", -1},
+		{Natural, "10", "9", 1},
+		{Lexical, "B", "a", -1},
+		{IgnoreCase(Lexical), "B", "a", 1},
+		{IgnoreCase(Lexical), "ABC", "abc", 0},
+		{IgnoreCase(Natural), "File10", "file9", 1},
+		{Version, "1.10.0", "1.9.0", 1},
+		{Version, "v1.2", "1.2.0", 0},
+		{Version, "1.2.0+build5", "1.2.0", 0},
+		{Version, "2.0.0-rc.1", "2.0.0", -1},
+		{Version, "2.0.0-alpha", "2.0.0-alpha.1", -1},
+		{Version, "2.0.0-alpha.10", "2.0.0-alpha.9", 1},
+		{Version, "2.0.0-beta", "2.0.0-alpha.9", 1},
+		{Version, "2.0.0-1", "2.0.0-alpha", -1},
+		{Version, "unknown", "1.0.0", 1},
+	}
+
+	for _, test := range tests {
+		got := test.cmp(test.a, test.b)
+		if (got < 0 && test.want >= 0) || (got > 0 && test.want <= 0) || (got == 0 && test.want != 0) {
+			t.Errorf("compare(%q, %q) = %d, want sign %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SortBy(0, IgnoreCase(Natural))
+	fmt.Fprint(w, "file10.txt\t3\n")
+	fmt.Fprint(w, "File2.txt\t1\n")
+	fmt.Fprint(w, "file2.txt\t2\n")
+	fmt.Fprint(w, "file1.txt\t4\n")
+	w.Flush()
+
+	want := "file1.txt  4\n" +
+		"File2.txt  1\n" +
+		"file2.txt  2\n" +
+		"file10.txt 3\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SortKeys([]SortKey{{Col: 2, Desc: true, Numeric: true}, {Col: 0}})
+	fmt.Fprint(w, "web\tus\t1,200\n")
+	fmt.Fprint(w, "db\teu\t80\n")
+	fmt.Fprint(w, "api\tus\t1,200\n")
+	fmt.Fprint(w, "cache\tus\tn/a\n")
+	fmt.Fprint(w, "api\teu\t1,200\n")
+	fmt.Fprint(w, "queue\teu\t95\n")
+	w.Flush()
+
+	want := "api   us 1,200\n" +
+		"api   eu 1,200\n" +
+		"web   us 1,200\n" +
+		"queue eu 95\n" +
+		"db    eu 80\n" +
+		"cache us n/a\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSortKeysNegative(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SortKeys([]SortKey{{Col: -1}, {Col: 0}})
+	w.GroupBy(0, Count(-1), Count(1))
+	fmt.Fprint(w, "b\t1\na\t2\nb\t3\n")
+	w.Flush()
+
+	want := "a 1\n" +
+		"b 2\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+// accentCollator orders letters with acute accents like the unaccented
+// letters, as a French collator would.
+type accentCollator struct{}
+
+func (accentCollator) CompareString(a, b string) int {
+	fold := strings.NewReplacer("é", "e", "É", "E", "á", "a").Replace
+	if c := strings.Compare(fold(a), fold(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func TestSetColumnCollator(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnCollator(0, accentCollator{})
+	w.SortKeys([]SortKey{{Col: 0}})
+	for _, name := range []string{"zèbre", "été", "etage", "abri"} {
+		fmt.Fprint(w, name+"\tx\n")
+	}
+	w.Flush()
+
+	want := "abri  x\n" +
+		"etage x\n" +
+		"été   x\n" +
+		"zèbre x\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	w.SetColumnCollator(0, nil)
+	for _, name := range []string{"zèbre", "été", "etage"} {
+		fmt.Fprint(w, name+"\tx\n")
+	}
+	w.Flush()
+
+	want = "etage x\n" +
+		"zèbre x\n" +
+		"été   x\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestLimitRows(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SortKeys([]SortKey{{Col: 1, Desc: true, Numeric: true}})
+	w.LimitRows(2, true)
+	for _, row := range []string{"a\t1", "b\t5", "c\t3", "d\t4", "e\t2"} {
+		fmt.Fprint(w, row+"\n")
+	}
+	w.Flush()
+
+	want := "b 5\n" +
+		"d 4\n" +
+		"\u2026 and 3 more\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSetMinRows(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetOutputFormat(FormatBorder)
+	w.UseFirstRowAsHeader(true)
+	w.SetMinRows(3)
+	fmt.Fprint(w, "NAME\tSIZE\n")
+	fmt.Fprint(w, "a.go\t12\n")
+	w.Flush()
+
+	want := "┌──────┬──────┐\n" +
+		"│ NAME │ SIZE │\n" +
+		"│ a.go │ 12   │\n" +
+		"│      │      │\n" +
+		"│      │      │\n" +
+		"└──────┴──────┘\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,81 @@
+package tabwriter
+
+import "fmt"
+
+// SQLRows is the subset of the methods of *sql.Rows used by WriteSQLRows,
+// which allows it to be used without depending on package database/sql.
+type SQLRows interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+// sqlNull is the text written in place of NULL values by WriteSQLRows.
+const sqlNull = "NULL"
+
+// WriteSQLRows writes the result of a database query as rows of the writer,
+// preceded by a row containing the names of the result's columns, much like
+// psql displays query results. NULL values are written as "NULL", and tabs
+// and line breaks within values are replaced by spaces. Columns whose non-NULL
+// values are all numbers are aligned right by the next flush, unless a format
+// was set for them with SetColumnFormat; if several results are written
+// before a flush, a column is aligned right only if it is numeric in all of
+// them. A result without columns writes nothing. WriteSQLRows reads all of
+// the rows but does not close them. It returns the first error reported by
+// rows. Like any rows, the result is aligned on the next flush.
+func (w *Writer) WriteSQLRows(rows SQLRows) error {
+	names, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	w.endRow()
+	w.writeRecord(names)
+
+	values := make([]interface{}, len(names))
+	dest := make([]interface{}, len(names))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	numeric := make([]bool, len(names))
+	for i := range numeric {
+		numeric[i] = true
+	}
+
+	cells := make([]string, len(names))
+	n := 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		for i, v := range values {
+			switch v := v.(type) {
+			case nil:
+				cells[i] = sqlNull
+				continue
+			case []byte:
+				cells[i] = string(v)
+			default:
+				cells[i] = fmt.Sprint(v)
+			}
+			numeric[i] = numeric[i] && isNumber(cells[i])
+		}
+		w.writeRecord(cells)
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if n > 0 {
+		for col, num := range numeric {
+			if col < len(w.sqlRight) {
+				w.sqlRight[col] = w.sqlRight[col] && num
+			} else {
+				w.sqlRight = append(w.sqlRight, num)
+			}
+		}
+	}
+	return nil
+}
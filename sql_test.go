@@ -0,0 +1,140 @@
+package tabwriter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeRows is an SQLRows returning fixed values.
+type fakeRows struct {
+	names  []string
+	values [][]interface{}
+	next   int
+	err    error
+}
+
+func (r *fakeRows) Columns() ([]string, error) { return r.names, nil }
+func (r *fakeRows) Err() error                 { return r.err }
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next <= len(r.values)
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	for i, v := range r.values[r.next-1] {
+		*dest[i].(*interface{}) = v
+	}
+	return nil
+}
+
+func TestWriteSQLRows(t *testing.T) {
+	rows := &fakeRows{
+		names: []string{"id", "name", "balance", "note"},
+		values: [][]interface{}{
+			{int64(1), []byte("alice"), 12.5, nil},
+			{int64(20), "bob", nil, "two\nlines"},
+			{int64(300), "carol", []byte("1000"), "ok"},
+		},
+	}
+
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	if err := w.WriteSQLRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+
+	want := " id name  balance note\n" +
+		"  1 alice    12.5 NULL\n" +
+		" 20 bob      NULL two lines\n" +
+		"300 carol    1000 ok\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	failure := errors.New("connection lost")
+	w = NewWriter(&b, 0, 8, 1, ' ', 0)
+	if err := w.WriteSQLRows(&fakeRows{names: []string{"id"}, err: failure}); err != failure {
+		t.Errorf("got error %v, want %v", err, failure)
+	}
+}
+
+func TestWriteSQLRowsFormat(t *testing.T) {
+	rows := &fakeRows{
+		names: []string{"id", "count", "note"},
+		values: [][]interface{}{
+			{int64(1), int64(5), "\xffa\tb"},
+			{int64(20), int64(10), ""},
+		},
+	}
+
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(0, 4, 1, 0)
+	if err := w.WriteSQLRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("got output before Flush: %q", b.String())
+	}
+	w.Flush()
+
+	want := "id  count note\n" +
+		"1       5 \xffa b\n" +
+		"20     10\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	b.Reset()
+	w = NewWriter(&b, 0, 8, 1, ' ', 0)
+	io.WriteString(w, "a\tb")
+	if err := w.WriteSQLRows(&fakeRows{values: [][]interface{}{{}, {}}}); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("got output before Flush: %q", b.String())
+	}
+	w.Flush()
+	if want := "a b\n"; b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestWriteSQLRowsFlushes(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	rows := &fakeRows{
+		names:  []string{"id", "name"},
+		values: [][]interface{}{{int64(1), "alice"}, {int64(22), "bo"}},
+	}
+	if err := w.WriteSQLRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+	if len(w.formatColumn) != 0 {
+		t.Errorf("WriteSQLRows set column formats %v", w.formatColumn)
+	}
+
+	rows = &fakeRows{
+		names:  []string{"name", "id"},
+		values: [][]interface{}{{"alice", int64(1)}, {"bo", int64(22)}},
+	}
+	if err := w.WriteSQLRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+
+	want := "id name\n" +
+		" 1 alice\n" +
+		"22 bo\n" +
+		"name  id\n" +
+		"alice 1\n" +
+		"bo    22\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
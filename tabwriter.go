@@ -19,16 +19,16 @@
 // Each '\r' that appears before a '\n' is output as another word-wrapped
 // newline/indent combo.
 //
-// This tabwriter always outputs a newline after a flush.
-//
-// This library does not support HTML filtering, escaped text sequences,
-// tab-indenting for padchar's other than '\t', or discarding of empty
-// columns.
+// By default, this tabwriter always outputs a newline after a flush, even
+// if the last line written is unterminated. SetTrailingNewline disables it.
 package tabwriter
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -37,24 +37,144 @@ const (
 	// left alignment.
 	AlignRight uint = 1 << iota
 
+	// AlignCenter centers a column's content within the column's width.
+	// It takes precedence over AlignRight.
+	AlignCenter
+
+	// AlignDecimal aligns the numbers in a column on their decimal
+	// separator, and right-aligns the column's other content. The separator
+	// is set by SetDecimalSeparator.
+	AlignDecimal
+
+	// SectionFormats scopes the column formats set by SetColumnFormat to
+	// the current alignment section: they are cleared whenever a section
+	// ends, at a form feed or a flush, so that a writer reused for tables of
+	// different shapes doesn't apply the formats of one table to the next.
+	// It only has an effect as one of the writer's flags.
+	SectionFormats
+
+	// DiscardEmptyColumns collapses the columns whose cells are all empty,
+	// as if they weren't present in the input, instead of outputting their
+	// padding. Like text/tabwriter, it applies to each block of cells sharing
+	// a column width, which usually spans the whole flush. It only affects
+	// the text output format, and only has an effect as one of the writer's
+	// flags.
+	DiscardEmptyColumns
+
+	// Debug outputs a vertical bar ('|') between the columns of each line,
+	// after the padding of each cell, which shows the columns' boundaries
+	// when tuning their format settings. It only affects the text output
+	// format, and only has an effect as one of the writer's flags.
+	Debug
+
+	// TabIndent pads the leading empty cells of each line, which indent
+	// the line, with tabs regardless of the padding character, like
+	// text/tabwriter. It only affects the text output format, and only has
+	// an effect as one of the writer's flags.
+	TabIndent
+
+	// FilterHTML ignores HTML tags, which occupy no columns, and treats
+	// HTML entities, which start with '&' and end with ';', as single
+	// characters, like text/tabwriter. Tabs and line breaks within tags and
+	// entities are part of the cell's text. It only has an effect as one of
+	// the writer's flags.
+	FilterHTML
+
+	// StripEscape removes the Escape characters bracketing escaped text
+	// from the output. Otherwise they are output along with the text.
+	StripEscape
+
+	// AlignNumeric aligns the numbers that begin the cells of a column,
+	// leaving any trailing annotation attached to its number, as in
+	// "123 MiB" or "45 ms*". The numbers are aligned on their decimal
+	// separator, and the annotations are left-aligned after them. The
+	// column's other content is right-aligned.
+	AlignNumeric
+
 	specified
 )
 
+// Escape brackets escaped text, whose characters are not interpreted: an
+// escaped tab, form feed or carriage return is part of the cell's text, and
+// an escaped line break continues the cell on another line, as in the cells
+// written by WriteRow. The Escape characters occupy no columns, and are
+// removed from the output by the StripEscape flag. For instance, the cell
+// "\xff\ta\xff" contains a tab followed by an "a".
+const Escape = '\xff'
+
 // A Writer is a filter that inserts padding around tab-delimited columns in
 // its input to align them in the output.
 type Writer struct {
-	output            io.Writer  // underlying output stream
-	tabwidth          int        // spaces between tab stops
-	padchar           byte       // character to use for cell padding
-	format            format     // default format
-	formatColumn      []format   // per-column format
-	formatColumnBits  uint64     // bit mask of valid formatColumn entries
-	formatDescription formatDesc // format settings for description rows
-
-	padbytes []byte       // array of padchars to use when padding
-	buf      bytes.Buffer // unformatted bytes accumulated until flush
-	lines    []line       // lines accumulated until flush
-	cell     cell         // current working cell
+	output            io.Writer         // underlying output stream
+	tabwidth          int               // spaces between tab stops
+	padchar           byte              // character to use for cell padding
+	format            format            // default format
+	formatColumn      []format          // per-column format
+	formatDescription formatDesc        // format settings for description rows
+	formatRow         formatRow         // format settings for overlong rows
+	columns           []column          // per-column settings
+	terminal          Terminal          // display device the output is shown on
+	annotationOffset  int               // columns between annotations and right edge
+	outputFormat      OutputFormat      // presentation of the aligned columns
+	formatPage        formatPage        // format settings for paginated output
+	autoAlign         bool              // right-align numeric columns on flush
+	normalizer        Renderer          // normalizes the text of every cell
+	bidiIsolate       bool              // isolate cells containing RTL text
+	sorting           []SortKey         // order of the rows in each flush
+	grouping          *grouping         // collapsing of the rows in each flush
+	pivot             *pivot            // cross-tabulation of the rows in each flush
+	limit             limit             // number of rows output by each flush
+	highlight         *highlight        // text emphasized in cells
+	redactionNote     string            // text appended to redacted cells
+	ellipsis          string            // marks the end of truncated text
+	headerNames       []string          // names of the columns
+	firstRowHeader    bool              // treat the first row as the header
+	fallbackFormat    OutputFormat      // output format used when not on a TTY
+	missingNull       bool              // export missing cells as null
+	namedColumns      []namedColumn     // configuration of columns by name
+	trace             io.Writer         // destination of layout decision logs
+	transformers      []LineTransformer // applied to each output line
+	statsCols         []int             // columns summarized by the stats footer
+	histogram         *histogram        // bucketing of the rows in each flush
+	title             string            // text output above each table
+	headerStyle       Style             // emphasizes the header row's cells
+	decimalSep        rune              // decimal separator of numbers (0 = '.')
+	leaderWidth       int               // width of rows joined by leaders (0 = none)
+	wideWidth         int               // width of rows handled by widePolicy (0 = terminal's)
+	widePolicy        WideLinePolicy    // how rows too wide for wideWidth are output
+	sectionThreshold  int               // column count change starting a section (0 = none)
+	widthFunc         func([]byte) int  // measures text widths (nil = displayWidth)
+	throttle          throttle          // rate of output to interactive terminals
+	schemaComment     bool              // begin TSV output with a schema comment
+	borderStyle       BorderStyle       // characters drawing the border format's grid
+	borderRules       Rules             // rules drawn by the border format
+	emptyPolicy       EmptyTablePolicy  // output of tables without rows
+	emptyText         string            // replaces the rows of empty tables
+	trimNewline       bool              // omit the newline of an unterminated last line
+	minRows           int               // rows each flush is padded to (0 = none)
+	blankSpacers      bool              // output blank lines without flushing
+
+	padbytes  []byte       // array of padchars to use when padding
+	buf       bytes.Buffer // unformatted bytes accumulated until flush
+	lines     []line       // lines accumulated until flush
+	cell      cell         // current working cell
+	linebuf   bytes.Buffer // formatted output of the line being written
+	header    []byte       // formatted first line of the current flush
+	pageLines int          // output lines written to the current page
+	err       error        // first error writing to the output stream
+	endChar   byte         // ends the escaped text, tag or entity being written (0 = none)
+
+	ctx          context.Context // context of the current FlushContext call
+	throttling   bool            // output of the current flush is throttled
+	chunkBytes   int             // bytes written since the last throttle pause
+	unterminated bool            // the last line of the current flush lacks a newline
+
+	alignRight  []bool       // columns auto-aligned right
+	sqlRight    []bool       // numeric columns of the results written by WriteSQLRows
+	leaderMin   int          // minimum width of the first column's leaders
+	flushFormat OutputFormat // output format of the current flush
+	children    []*Writer    // indented writers flushed into this one
+	footnotes   []string     // footnotes output after the current flush
 
 	addCell  func(w *Writer, term bool)
 	descmode bool // currently in description update mode
@@ -65,6 +185,7 @@ type format struct {
 	minwidth int  // minimum width of cell including padding
 	padding  int  // number of extra padding chars in a cell
 	flags    uint // format flags
+	padchar  byte // overrides the writer's padding character (0 = none)
 }
 
 // formatDesc describes the settings to use for description text output.
@@ -73,21 +194,64 @@ type formatDesc struct {
 	wordwrap int // Column at which to word-wrap descriptions
 }
 
+// formatRow describes the settings to use when wrapping overlong rows.
+type formatRow struct {
+	wrap   int    // Column at which to wrap rows (0 = never)
+	prefix string // Prefix output at the start of each continuation line
+}
+
+// formatPage describes the settings to use when paginating output.
+type formatPage struct {
+	length int    // Output lines per page (0 = no pagination)
+	brk    string // Sequence output between pages
+	header bool   // Repeat the flush's first line at the top of each page
+}
+
+// column holds the per-column settings other than the column's format.
+type column struct {
+	renderer   Renderer // transforms cell text before it is measured
+	wrap       wrap     // settings for wrapping cell text
+	reserve    int      // width of text to reserve space for
+	sizing     Sizing   // computes the width of the column's text
+	overflow   Overflow // how to output cells too wide for the column
+	minContent bool     // size a wrapping column to its longest word
+	collapse   bool     // collapse runs of whitespace in cell text
+	redactor   Renderer // hides sensitive cell text
+	unit       string   // unit of the column's values, shown in its heading
+	stripUnit  bool     // remove the unit from the column's values
+	collator   Collator // compares cell text when sorting by the column
+	padchar    byte     // character to use for padding (0 = writer's)
+	maxWidth   int      // width cell text is truncated to (0 = none)
+}
+
 type cell struct {
-	size     int  // number of bytes in cell
-	width    int  // number of runes in the cell
-	maxwidth int  // maximum width seen in this cell's column so far
-	term     bool // last cell in line
+	start    int       // offset of cell's text in the buffer
+	size     int       // number of bytes in cell
+	width    int       // number of columns the cell occupies
+	maxwidth int       // width of the cell's column including padding
+	term     bool      // last cell in line
+	segs     []segment // lines of a wrapped cell (nil if not wrapped)
+	extra    int       // padding requested after the cell
+	block    bool      // pre-rendered text written by WriteBlock
 }
 
 type line struct {
-	cells       []cell // All non-description cells in the row
-	description cell   // The description cell (if any)
+	cells       []cell  // All non-description cells in the row
+	description cell    // The description cell (if any)
+	raw         cell    // Passthrough text replacing the row (if any)
+	groups      []Group // Group header replacing the row (if any)
+	annotation  string  // Text output at the right edge of the terminal
+	meta        []Meta  // Hidden metadata attached to the row's cells
+	emptyEnd    bool    // The row ends with an explicitly empty cell
+	header      bool    // The row is the header of the table
+	style       Style   // Emphasizes the row's cells
 }
 
 var (
 	newline = []byte{'\n'}
 	space   = []byte{' '}
+	fsi     = []byte("\u2068") // first strong isolate
+	pdi     = []byte("\u2069") // pop directional isolate
 )
 
 func min(a, b int) int {
@@ -109,15 +273,122 @@ func (w *Writer) reset() {
 	w.buf.Reset()
 	w.cell = cell{}
 	w.lines = w.lines[0:0]
+	w.endChar = 0
+	w.alignRight = nil
+	w.sqlRight = nil
+	w.footnotes = nil
 	w.addNewLine()
 }
 
 // getFlags returns the tabwriter flags that should be used for column col.
 func (w *Writer) getFormat(col int) format {
-	if col >= 64 || w.formatColumnBits&(uint64(1)<<uint(col)) == 0 {
-		return w.format
+	f := w.columnFormat(col)
+	if col < len(w.alignRight) && w.alignRight[col] {
+		f.flags |= AlignRight
 	}
-	return w.formatColumn[col]
+	if col < len(w.sqlRight) && w.sqlRight[col] && !w.hasColumnFormat(col) {
+		f.flags |= AlignRight
+	}
+	if f.flags&(AlignDecimal|AlignNumeric) != 0 {
+		f.flags |= AlignRight
+	}
+	if col == 0 {
+		f.minwidth = max(f.minwidth, w.leaderMin)
+	}
+	f.padchar = w.getColumn(col).padchar
+	return f
+}
+
+// hasColumnFormat returns true if a format was set for column col by
+// SetColumnFormat.
+func (w *Writer) hasColumnFormat(col int) bool {
+	return col < len(w.formatColumn) && w.formatColumn[col].flags&specified != 0
+}
+
+// columnFormat returns the format set for column col by SetColumnFormat, or
+// the writer's format if none was set.
+func (w *Writer) columnFormat(col int) format {
+	if w.hasColumnFormat(col) {
+		f := w.formatColumn[col]
+		f.flags &^= specified
+		return f
+	}
+	return w.format
+}
+
+// getColumn returns the settings that should be used for column col.
+func (w *Writer) getColumn(col int) column {
+	if col >= len(w.columns) {
+		return column{}
+	}
+	return w.columns[col]
+}
+
+// setColumn returns the settings for column col so they may be modified.
+func (w *Writer) setColumn(col int) *column {
+	if col >= len(w.columns) {
+		c := make([]column, col+1)
+		copy(c, w.columns)
+		w.columns = c
+	}
+	return &w.columns[col]
+}
+
+// renderCell replaces the contents of the working cell with the output of
+// the renderer r.
+func (w *Writer) renderCell(r Renderer) {
+	b := w.buf.Bytes()
+	text := r(string(b[len(b)-w.cell.size:]))
+	w.buf.Truncate(len(b) - w.cell.size)
+	w.cell.size = 0
+	w.addTextToCell([]byte(text))
+}
+
+// collapseSpace replaces each run of whitespace in text with a single space
+// and trims whitespace from both ends.
+func collapseSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// redact returns a renderer that applies redactor r and appends the writer's
+// redaction note to text that r changes.
+func (w *Writer) redact(r Renderer) Renderer {
+	return func(text string) string {
+		redacted := r(text)
+		if redacted != text {
+			redacted += w.redactionNote
+		}
+		return redacted
+	}
+}
+
+// unitRenderer returns a renderer that appends a column's unit to the
+// heading in the first row, or strips the unit from the column's values if
+// requested.
+func (w *Writer) unitRenderer(settings column) Renderer {
+	return func(text string) string {
+		if w.isFirstRow() {
+			return text + " (" + settings.unit + ")"
+		}
+		if settings.stripUnit {
+			trimmed := strings.TrimRightFunc(text, unicode.IsSpace)
+			if t := strings.TrimSuffix(trimmed, settings.unit); t != trimmed {
+				return strings.TrimRightFunc(t, unicode.IsSpace)
+			}
+		}
+		return text
+	}
+}
+
+// isFirstRow returns true if the working line is the first row buffered
+// since the last flush.
+func (w *Writer) isFirstRow() bool {
+	for _, l := range w.lines[:len(w.lines)-1] {
+		if l.raw.size == 0 && l.groups == nil {
+			return false
+		}
+	}
+	return true
 }
 
 // addTextToCell updates the contents of the working cell.
@@ -129,10 +400,6 @@ func (w *Writer) addTextToCell(text []byte) {
 // addCellToLine finalizes the working cell and appends it to the working
 // line.
 func (w *Writer) addCellToLine(term bool) {
-	// Calculate the cell's width (the number of runes).
-	b := w.buf.Bytes()
-	w.cell.width = utf8.RuneCount(b[len(b)-w.cell.size:])
-
 	linecount := len(w.lines)
 	line := &w.lines[linecount-1]
 
@@ -142,9 +409,10 @@ func (w *Writer) addCellToLine(term bool) {
 		// If the current line is empty, flush. Otherwise, mark the previous
 		// cell in the line as the terminator.
 		if len(line.cells) == 0 {
-			w.Flush()
+			w.flush()
 		} else {
 			line.cells[len(line.cells)-1].term = true
+			line.emptyEnd = true
 		}
 
 		w.cell = cell{}
@@ -154,18 +422,41 @@ func (w *Writer) addCellToLine(term bool) {
 	w.cell.term = term
 
 	col := len(line.cells)
-	format := w.getFormat(col)
-
-	w.cell.maxwidth = max(format.minwidth, w.cell.width+format.padding)
-	if linecount > 1 {
-		// Examine the cell in the previous line at the same column. Compute
-		// this cell's maxwidth based on that cell's maxwidth and this cell's
-		// width. This causes the maxwidth for each column to accumulate
-		// downwards. When we flush, we'll traverse the lines in reverse and
-		// copy the per-column maxwidth values upwards.
-		prev := &w.lines[linecount-2]
-		if col < len(prev.cells)-1 {
-			w.cell.maxwidth = max(w.cell.maxwidth, prev.cells[col].maxwidth)
+	settings := w.getColumn(col)
+	if w.cell.block {
+		w.addBlockToLine(line)
+		return
+	}
+	if w.normalizer != nil {
+		w.renderCell(w.normalizer)
+	}
+	if settings.collapse {
+		w.renderCell(collapseSpace)
+	}
+	if settings.redactor != nil {
+		w.renderCell(w.redact(settings.redactor))
+	}
+	if settings.unit != "" {
+		w.renderCell(w.unitRenderer(settings))
+	}
+	if settings.renderer != nil {
+		w.renderCell(settings.renderer)
+	}
+
+	// Calculate the cell's width (the number of columns it occupies).
+	w.cell.start = w.buf.Len() - w.cell.size
+	text := w.buf.Bytes()[w.cell.start:]
+	w.cell.width = w.measure(text)
+
+	// Wrap the cell if it's too wide for its column. Cells that may not be
+	// broken overflow the column instead. Cells written by WriteRow may
+	// contain several lines.
+	if bytes.IndexByte(text, '\n') >= 0 {
+		w.splitCell(&w.cell, settings.wrap)
+	} else if wrap := settings.wrap; wrap.width > 0 && w.cell.width > wrap.width {
+		if wrap.policy != BreakNone {
+			w.wrapCell(&w.cell, wrap.width, wrap.policy)
+			w.tracef("column %d: wrapped %q at width %d onto %d lines", col, text, wrap.width, len(w.cell.segs))
 		}
 	}
 
@@ -176,71 +467,269 @@ func (w *Writer) addCellToLine(term bool) {
 // addCellToLine finalizes the working cell and sets the working line's
 // description to it.
 func (w *Writer) addCellToDescription(term bool) {
-	b := w.buf.Bytes()
-	w.cell.width = utf8.RuneCount(b[len(b)-w.cell.size:])
+	if w.normalizer != nil {
+		w.renderCell(w.normalizer)
+	}
+	w.cell.start = w.buf.Len() - w.cell.size
+	w.cell.width = w.measure(w.buf.Bytes()[w.cell.start:])
 	w.lines[len(w.lines)-1].description = w.cell
 	w.cell = cell{}
 }
 
 // addNewLine adds a new, empty line to the working set.
 func (w *Writer) addNewLine() {
-	w.lines = append(w.lines, line{[]cell{}, cell{}})
+	if n := len(w.lines); n > 0 && len(w.lines[n-1].cells) > 0 && w.headerIndex() == n-1 {
+		w.resolveColumnNames(w.cellTexts(w.lines[n-1]))
+	}
+	w.lines = append(w.lines, line{cells: []cell{}})
 	w.addCell = (*Writer).addCellToLine
 	w.descmode = false
 }
 
-// tabifyLine adjusts the maxwidth of each cell in a line so that each
-// cell begins on a tab stop.
-func (w *Writer) tabifyLine(line *line) {
-	for i := range line.cells {
-		c := &line.cells[i]
-		remainder := c.maxwidth % w.tabwidth
-		if remainder != 0 {
-			c.maxwidth += w.tabwidth - remainder
-		}
+// tabify rounds width up so that the following cell begins on a tab stop.
+func (w *Writer) tabify(width int) int {
+	remainder := width % w.tabwidth
+	if remainder != 0 {
+		width += w.tabwidth - remainder
 	}
+	return width
 }
 
 // writeCell outputs a cell's contents and its padding.
 func (w *Writer) writeCell(text []byte, padding int, format format, term bool) {
+	text, padding, format = w.anchorCell(text, padding, format)
+	text = w.highlightText(text)
+	if w.bidiIsolate && hasRTL(text) {
+		text = append(append(append([]byte(nil), fsi...), text...), pdi...)
+	}
+
+	writePadding := w.writePadding
+	if format.padchar != 0 {
+		writePadding = func(n int) {
+			w.linebuf.Write(bytes.Repeat([]byte{format.padchar}, n))
+		}
+	}
+
 	switch {
 	case padding == 0:
 		fallthrough
-	case term && (format.flags&AlignRight == 0):
+	case term && (format.flags&(AlignRight|AlignCenter) == 0):
 		// Don't pad the terminating cell in a left-aligned line.
-		w.output.Write(text)
+		w.linebuf.Write(text)
 
 	case w.padchar == '\t':
-		// Write text and then pad with tabs. Never right-align when padding
-		// with tabs.
-		w.output.Write(text)
+		// Write text and then pad with tabs. Never right-align or center
+		// when padding with tabs.
+		w.linebuf.Write(text)
 		w.writePadding((padding + w.tabwidth - 1) / w.tabwidth)
 
+	case (format.flags & AlignCenter) != 0:
+		// When centering, split the padding around the text, leaving the
+		// odd pad character and the one separating the columns on the
+		// right side.
+		left := (padding - 1) / 2
+		writePadding(left)
+		w.linebuf.Write(text)
+		if !term {
+			writePadding(padding - left)
+		}
+
 	case (format.flags & AlignRight) != 0:
 		// When aligning right, use one of the pad characters on the right
 		// side of the text. This way, two adjacent columns that are align-
 		// right and align-left will not touch one another.
-		w.writePadding(padding - 1)
-		w.output.Write(text)
+		writePadding(padding - 1)
+		w.linebuf.Write(text)
 		if !term {
-			w.writePadding(1)
+			writePadding(1)
 		}
 
 	default:
 		// When aligning left, pad on the right.
-		w.output.Write(text)
-		w.writePadding(padding)
+		w.linebuf.Write(text)
+		writePadding(padding)
+	}
+}
+
+// hasRTL returns true if text contains characters of a right-to-left script.
+func hasRTL(text []byte) bool {
+	for _, r := range string(text) {
+		if r >= 0x0590 && unicode.In(r, rtlScripts...) {
+			return true
+		}
 	}
+	return false
+}
+
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana,
+	unicode.Nko, unicode.Samaritan, unicode.Mandaic, unicode.Adlam,
+}
+
+// cellText returns the buffered text of cell c.
+func (w *Writer) cellText(c cell) []byte {
+	return w.buf.Bytes()[c.start : c.start+c.size]
+}
+
+// cellLine returns the text and width of output line k of cell c. Cells that
+// have not been wrapped have a single line.
+func (w *Writer) cellLine(c cell, k int) ([]byte, int) {
+	text := w.cellText(c)
+	switch {
+	case c.segs == nil && k == 0:
+		return text, c.width
+	case k >= len(c.segs):
+		return nil, 0
+	default:
+		s := c.segs[k]
+		if s.hyphen {
+			// Replace the soft hyphen ending the segment with a visible one.
+			_, size := utf8.DecodeLastRune(text[s.start : s.start+s.size])
+			hyphenated := append([]byte(nil), text[s.start:s.start+s.size-size]...)
+			return append(hyphenated, '-'), s.width
+		}
+		return text[s.start : s.start+s.size], s.width
+	}
+}
+
+// cellPadding returns the number of pad characters that follow width
+// columns of cell c's text. Cells overflowing their column receive the
+// column's minimum padding.
+func cellPadding(c cell, width int, format format) int {
+	if c.maxwidth == 0 {
+		// The cell's column was discarded.
+		return 0
+	}
+	return max(c.maxwidth-width, format.padding)
+}
+
+// writeLine outputs a line's cells. A line containing wrapped cells is
+// output on as many lines as its tallest cell requires.
+func (w *Writer) writeLine(l line) {
+	height := 1
+	for _, c := range l.cells {
+		height = max(height, len(c.segs))
+	}
+
+	breaks := w.rowBreaks(l)
+	for k := 0; k < height; k++ {
+		// Continuation lines end with the last cell that still has text.
+		last := len(l.cells) - 1
+		if k > 0 {
+			for last >= 0 {
+				if text, _ := w.cellLine(l.cells[last], k); len(text) > 0 {
+					break
+				}
+				last--
+			}
+		}
+
+		col := 0
+		indent := w.format.flags&TabIndent != 0 && w.tabwidth > 0
+		for j, c := range l.cells[:last+1] {
+			switch {
+			case breaks[j]:
+				w.linebuf.Write(newline)
+				w.linebuf.WriteString(w.formatRow.prefix)
+				col = w.measure([]byte(w.formatRow.prefix))
+			case j > 0 && w.format.flags&Debug != 0:
+				w.linebuf.WriteByte('|')
+				col++
+			}
+
+			// A cell followed by a row break terminates its output line.
+			term := c.term || (j+1 < len(breaks) && breaks[j+1])
+			if k > 0 {
+				term = term || j == last
+			}
+
+			text, width := w.cellLine(c, k)
+			format := w.getFormat(j)
+			padding := cellPadding(c, width, format)
+			if indent && len(text) == 0 && !term {
+				n := (padding + w.tabwidth - 1) / w.tabwidth
+				w.linebuf.Write(bytes.Repeat([]byte{'\t'}, n))
+				col += n * w.tabwidth
+				continue
+			}
+			indent = false
+			if l.header {
+				text = w.styleHeader(text)
+			}
+			w.writeCell(l.style.apply(text), padding, format, term)
+			col += w.cellSpan(width, padding, format, term)
+		}
+		if k == 0 && l.annotation != "" {
+			w.writeAnnotation(l.annotation, col)
+		}
+		w.linebuf.Write(newline)
+	}
+}
+
+// cellSpan returns the number of columns writeCell uses to output a cell of
+// the given width and padding.
+func (w *Writer) cellSpan(width, padding int, format format, term bool) int {
+	switch {
+	case padding == 0:
+		fallthrough
+	case term && (format.flags&(AlignRight|AlignCenter) == 0):
+		return width
+
+	case w.padchar == '\t':
+		return width + (padding+w.tabwidth-1)/w.tabwidth*w.tabwidth
+
+	case term && (format.flags&AlignCenter) != 0:
+		return width + (padding-1)/2
+
+	case term:
+		// Right-aligned terminating cells have no trailing pad character.
+		return width + padding - 1
+
+	default:
+		return width + padding
+	}
+}
+
+// rowBreaks determines which of a line's cells must be output at the start of
+// a continuation line in order to keep the row within the wrap column.
+func (w *Writer) rowBreaks(l line) []bool {
+	breaks := make([]bool, len(l.cells))
+	if w.formatRow.wrap <= 0 {
+		return breaks
+	}
+
+	col := 0
+	for j, c := range l.cells {
+		format := w.getFormat(j)
+		span := w.cellSpan(c.width, cellPadding(c, c.width, format), format, c.term)
+		if j > 0 && col+span > w.formatRow.wrap {
+			breaks[j] = true
+			col = w.measure([]byte(w.formatRow.prefix))
+		}
+		col += span
+	}
+	return breaks
 }
 
 func (w *Writer) writeDescription(text []byte) {
+	w.writeWrapped(text, "", w.formatDescription.indent)
+}
+
+// writeWrapped outputs text word-wrapped at the description wrap column. Each
+// line of the text, separated by '\r', is indented by indent columns, except
+// that a non-empty prefix replaces the indent of the first line.
+func (w *Writer) writeWrapped(text []byte, prefix string, indent int) {
 	for p := 0; p < len(text); {
 
 		// Output indent.
-		col := w.formatDescription.indent
-		if w.padchar == '\t' {
+		col := indent
+		switch {
+		case p == 0 && prefix != "":
+			w.linebuf.WriteString(prefix)
+			col = w.measure([]byte(prefix))
+		case w.padchar == '\t':
 			w.writePadding((col + w.tabwidth - 1) / w.tabwidth)
-		} else {
+		default:
 			w.writePadding(col)
 		}
 
@@ -249,8 +738,8 @@ func (w *Writer) writeDescription(text []byte) {
 		p0, lastspace := p, -1
 		for {
 			if p >= len(text) || text[p] == '\r' {
-				w.output.Write(text[p0:p])
-				w.output.Write(newline)
+				w.linebuf.Write(text[p0:p])
+				w.linebuf.Write(newline)
 				p++
 				break
 			}
@@ -259,13 +748,13 @@ func (w *Writer) writeDescription(text []byte) {
 				lastspace = p
 			}
 
-			_, size := utf8.DecodeRune(text[p:])
+			_, size, _ := nextCluster(text[p:])
+			col += w.measure(text[p : p+size])
 			p += size
-			col++
 
 			if col > w.formatDescription.wordwrap && lastspace != -1 {
-				w.output.Write(text[p0:lastspace])
-				w.output.Write(newline)
+				w.linebuf.Write(text[p0:lastspace])
+				w.linebuf.Write(newline)
 				p = lastspace + 1
 				break
 			}
@@ -273,24 +762,93 @@ func (w *Writer) writeDescription(text []byte) {
 	}
 }
 
+// writeAnnotation outputs a row annotation so that it ends annotationOffset
+// columns from the right edge of the terminal. The annotation is separated
+// from the row by a single space when the terminal's width is unknown or the
+// row is too wide for the annotation to fit.
+func (w *Writer) writeAnnotation(text string, col int) {
+	gap := 1
+	if w.terminal != nil {
+		width := w.terminal.Width()
+		if width > 0 {
+			start := width - w.annotationOffset - w.measure([]byte(text))
+			gap = max(gap, start-col)
+		}
+	}
+	w.linebuf.Write(bytes.Repeat(space, gap))
+	w.linebuf.WriteString(text)
+}
+
+// emitLine writes the formatted output held in the line buffer to the
+// underlying stream. If the output is paginated and the text doesn't fit on
+// the current page, a page break is output first. The first table line of
+// each flush is remembered as the header repeated at the top of each page.
+func (w *Writer) emitLine(table bool) {
+	text := w.transformLines(w.linebuf.Bytes())
+	header := table && w.header == nil
+	if header {
+		w.header = append([]byte{}, text...)
+	}
+
+	n := bytes.Count(text, newline)
+	if p := w.formatPage; p.length > 0 && w.pageLines > 0 && w.pageLines+n > p.length {
+		w.write([]byte(p.brk))
+		w.pageLines = 0
+		if p.header && !header {
+			w.write(w.header)
+			w.pageLines += bytes.Count(w.header, newline)
+		}
+	}
+
+	w.write(text)
+	w.pageLines += n
+	w.linebuf.Reset()
+}
+
+// write writes text to the underlying stream. Once a write fails, the error
+// is recorded and no further text is written until the writer is reset.
+func (w *Writer) write(text []byte) {
+	if w.err == nil && w.ctx != nil {
+		w.err = w.ctx.Err()
+	}
+	if w.err != nil {
+		return
+	}
+	if w.throttling {
+		w.writeThrottled(text)
+		return
+	}
+	if _, err := w.output.Write(text); err != nil {
+		w.err = err
+	}
+}
+
 // writePadding outputs n pad characters.
 func (w *Writer) writePadding(n int) {
 	for n > len(w.padbytes) {
-		w.output.Write(w.padbytes)
+		w.linebuf.Write(w.padbytes)
 		n -= len(w.padbytes)
 	}
-	w.output.Write(w.padbytes[:n])
+	w.linebuf.Write(w.padbytes[:n])
 }
 
 // NewWriter creates and initializes a new tabwriter.Writer.
 func NewWriter(output io.Writer, minwidth, tabwidth, padding int, padchar byte, flags uint) *Writer {
+	d := Defaults()
 	w := &Writer{
 		output:            output,
 		tabwidth:          tabwidth,
 		padchar:           padchar,
-		format:            format{minwidth, padding, flags},
+		format:            format{minwidth: minwidth, padding: padding, flags: flags},
 		formatColumn:      []format{},
-		formatDescription: formatDesc{8, 72},
+		formatDescription: formatDesc{d.DescriptionIndent, d.DescriptionWrap},
+		formatRow:         formatRow{d.RowWrap, d.RowPrefix},
+		formatPage:        formatPage{brk: "\f"},
+		limit:             limit{rows: -1},
+		fallbackFormat:    noFallback,
+		ellipsis:          defaultEllipsis,
+		borderStyle:       BorderLight,
+		borderRules:       RuleOuter | RuleColumns,
 		padbytes:          bytes.Repeat([]byte{padchar}, 8),
 	}
 	w.reset()
@@ -311,19 +869,146 @@ func (w *Writer) Init(output io.Writer, minwidth, tabwidth, padding int, padchar
 	w.output = output
 	w.tabwidth = tabwidth
 	w.padchar = padchar
-	w.format = format{minwidth, padding, flags}
+	w.format = format{minwidth: minwidth, padding: padding, flags: flags}
+	d := Defaults()
 	w.formatColumn = []format{}
-	w.formatDescription = formatDesc{8, 72}
+	w.formatDescription = formatDesc{d.DescriptionIndent, d.DescriptionWrap}
+	w.formatRow = formatRow{d.RowWrap, d.RowPrefix}
+	w.columns = nil
+	w.terminal = nil
+	w.annotationOffset = 0
+	w.outputFormat = FormatText
+	w.formatPage = formatPage{brk: "\f"}
+	w.autoAlign = false
+	w.normalizer = nil
+	w.bidiIsolate = false
+	w.sorting = nil
+	w.grouping = nil
+	w.pivot = nil
+	w.limit = limit{rows: -1}
+	w.highlight = nil
+	w.redactionNote = ""
+	w.ellipsis = defaultEllipsis
+	w.headerNames = nil
+	w.firstRowHeader = false
+	w.fallbackFormat = noFallback
+	w.missingNull = false
+	w.namedColumns = nil
+	w.trace = nil
+	w.transformers = nil
+	w.statsCols = nil
+	w.histogram = nil
+	w.title = ""
+	w.children = nil
+	w.headerStyle = Style{}
+	w.decimalSep = 0
+	w.leaderWidth = 0
+	w.wideWidth = 0
+	w.widePolicy = WideLineAlign
+	w.sectionThreshold = 0
+	w.widthFunc = nil
+	w.throttle = throttle{}
+	w.schemaComment = false
+	w.borderStyle = BorderLight
+	w.borderRules = RuleOuter | RuleColumns
+	w.emptyPolicy = EmptyTableHeader
+	w.emptyText = ""
+	w.trimNewline = false
+	w.minRows = 0
+	w.blankSpacers = false
+	w.pageLines = 0
+	w.err = nil
+	w.chunkBytes = 0
 	w.padbytes = bytes.Repeat([]byte{padchar}, 8)
 	w.reset()
 	return w
 }
 
+// Clone creates a new tabwriter.Writer that writes to output and shares all
+// of w's configuration settings. None of w's buffered text is copied.
+func (w *Writer) Clone(output io.Writer) *Writer {
+	c := &Writer{
+		output:            output,
+		tabwidth:          w.tabwidth,
+		padchar:           w.padchar,
+		format:            w.format,
+		formatColumn:      append([]format{}, w.formatColumn...),
+		formatDescription: w.formatDescription,
+		formatRow:         w.formatRow,
+		columns:           append([]column(nil), w.columns...),
+		terminal:          w.terminal,
+		annotationOffset:  w.annotationOffset,
+		outputFormat:      w.outputFormat,
+		formatPage:        w.formatPage,
+		autoAlign:         w.autoAlign,
+		normalizer:        w.normalizer,
+		bidiIsolate:       w.bidiIsolate,
+		sorting:           append([]SortKey(nil), w.sorting...),
+		grouping:          w.grouping,
+		pivot:             w.pivot,
+		limit:             w.limit,
+		highlight:         w.highlight,
+		redactionNote:     w.redactionNote,
+		ellipsis:          w.ellipsis,
+		headerNames:       append([]string(nil), w.headerNames...),
+		firstRowHeader:    w.firstRowHeader,
+		fallbackFormat:    w.fallbackFormat,
+		missingNull:       w.missingNull,
+		namedColumns:      append([]namedColumn(nil), w.namedColumns...),
+		trace:             w.trace,
+		transformers:      append([]LineTransformer(nil), w.transformers...),
+		statsCols:         append([]int(nil), w.statsCols...),
+		histogram:         w.histogram,
+		title:             w.title,
+		headerStyle:       w.headerStyle,
+		decimalSep:        w.decimalSep,
+		leaderWidth:       w.leaderWidth,
+		wideWidth:         w.wideWidth,
+		widePolicy:        w.widePolicy,
+		sectionThreshold:  w.sectionThreshold,
+		widthFunc:         w.widthFunc,
+		throttle:          w.throttle,
+		schemaComment:     w.schemaComment,
+		borderStyle:       w.borderStyle,
+		borderRules:       w.borderRules,
+		emptyPolicy:       w.emptyPolicy,
+		emptyText:         w.emptyText,
+		trimNewline:       w.trimNewline,
+		minRows:           w.minRows,
+		blankSpacers:      w.blankSpacers,
+		padbytes:          w.padbytes,
+	}
+	c.reset()
+	return c
+}
+
 // Write writes buf to the writer w, returning the number of bytes written
-// and any errors encountered while writing to the underlying stream.
+// and any errors encountered while writing to the underlying stream. Once
+// writing to the stream has failed, Write returns the error without
+// buffering buf until Reset is called.
 func (w *Writer) Write(buf []byte) (n int, err error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	defer func() {
+		err = w.err
+	}()
+
 	n = 0
 	for i, ch := range buf {
+		if w.endChar != 0 {
+			// Escaped text, and text within HTML tags and entities, is not
+			// interpreted.
+			if ch == w.endChar {
+				w.endChar = 0
+				if ch == Escape && w.format.flags&StripEscape != 0 {
+					w.addTextToCell(buf[n:i])
+					n = i + 1
+				}
+			}
+			continue
+		}
+
 		switch ch {
 		case '\t', '\v':
 			w.addTextToCell(buf[n:i])
@@ -338,7 +1023,11 @@ func (w *Writer) Write(buf []byte) (n int, err error) {
 
 		case '\n':
 			w.addTextToCell(buf[n:i])
-			w.addCell(w, true)
+			if w.blankSpacers && w.blankLine() {
+				w.lines[len(w.lines)-1].raw = w.newCell("\n")
+			} else {
+				w.addCell(w, true)
+			}
 			n = i + 1
 			w.addNewLine()
 
@@ -346,7 +1035,7 @@ func (w *Writer) Write(buf []byte) (n int, err error) {
 			w.addTextToCell(buf[n:i])
 			w.addCell(w, true)
 			n = i + 1
-			w.Flush()
+			w.flush()
 
 		case '\r':
 			if !w.descmode {
@@ -356,6 +1045,18 @@ func (w *Writer) Write(buf []byte) (n int, err error) {
 				w.descmode = true
 				w.addCell = (*Writer).addCellToDescription
 			}
+
+		case '<', '&':
+			if w.format.flags&FilterHTML != 0 {
+				w.endChar = htmlEnd(ch)
+			}
+
+		case Escape:
+			w.endChar = Escape
+			if w.format.flags&StripEscape != 0 {
+				w.addTextToCell(buf[n:i])
+				n = i + 1
+			}
 		}
 	}
 
@@ -364,9 +1065,219 @@ func (w *Writer) Write(buf []byte) (n int, err error) {
 	return
 }
 
-// Flush triggers the formatting and output of tabbed text to the underlying
-// stream.
-func (w *Writer) Flush() {
+// rowPending returns true if a row has been partially written.
+func (w *Writer) rowPending() bool {
+	return w.cell.size > 0 || w.descmode || len(w.lines[len(w.lines)-1].cells) > 0
+}
+
+// endRow terminates a partially written row.
+func (w *Writer) endRow() {
+	if w.rowPending() {
+		w.addCell(w, true)
+		w.addNewLine()
+	}
+}
+
+// WriteRaw writes text to the output verbatim, without interpreting any of
+// its characters. Unlike a flush, raw text does not end the current alignment
+// section: the rows written before and after it continue to share column
+// widths. A partially written row is terminated before the raw text.
+func (w *Writer) WriteRaw(text []byte) {
+	w.endRow()
+	if len(text) == 0 {
+		return
+	}
+
+	w.addTextToCell(text)
+	w.cell.start = w.buf.Len() - w.cell.size
+	w.lines[len(w.lines)-1].raw = w.cell
+	w.cell = cell{}
+	w.addNewLine()
+}
+
+// SetBlankLineSpacers sets whether blank lines are output as spacer rows.
+// By default, a blank line flushes the writer, so that the rows before and
+// after it are aligned independently. When enabled, blank lines are output
+// as they are, like raw text, and the rows around them continue to share
+// column widths.
+func (w *Writer) SetBlankLineSpacers(enabled bool) {
+	w.blankSpacers = enabled
+}
+
+// blankLine returns true if the line being written is empty so far.
+func (w *Writer) blankLine() bool {
+	return w.cell.size == 0 && !w.descmode && len(w.lines[len(w.lines)-1].cells) == 0
+}
+
+// PadCell requests extra pad characters after the cell currently being
+// written, in addition to its column's padding. The other cells sharing the
+// cell's column widths are widened to match, so the extra space separates the
+// columns on either side, such as two logical groups of columns, without
+// changing the column's format.
+func (w *Writer) PadCell(extra int) {
+	w.cell.extra = extra
+}
+
+// rowClean replaces the characters of WriteRow cells that would be
+// interpreted as table structure, other than line breaks.
+var rowClean = strings.NewReplacer("\r\n", "\n", "\t", " ", "\v", " ", "\r", " ", "\f", " ")
+
+// WriteRow writes a row of cells. Unlike text written with Write, a cell may
+// contain line breaks: its lines are output one below the other, and the row
+// is expanded vertically to fit its tallest cell, keeping all of its columns
+// aligned. Tabs and other control characters within cells are replaced by
+// spaces. A partially written row is terminated first.
+func (w *Writer) WriteRow(cells ...string) {
+	w.endRow()
+	w.addRow(cells, rowClean)
+}
+
+// addRow adds cells, cleaned by clean, as the working line and begins a new
+// one. A trailing empty cell is recorded like one written with Write.
+func (w *Writer) addRow(cells []string, clean *strings.Replacer) {
+	if len(cells) == 1 && cells[0] == "" {
+		// Terminating an empty row flushes, so the lone empty cell
+		// terminates the row itself.
+		w.addCell(w, false)
+		w.lines[len(w.lines)-1].cells[0].term = true
+		w.addNewLine()
+		return
+	}
+	for j, text := range cells {
+		w.addTextToCell([]byte(clean.Replace(text)))
+		w.addCell(w, j == len(cells)-1)
+	}
+	w.addNewLine()
+}
+
+// Annotate attaches text to the row currently being written. The text is
+// output at the end of the row, aligned to the right edge of the terminal
+// independently of the table's columns.
+func (w *Writer) Annotate(text string) {
+	w.lines[len(w.lines)-1].annotation = text
+}
+
+// Flush should be called after the last call to Write to ensure that any
+// data buffered in the Writer is written to output. It returns the first
+// error encountered while writing to the underlying stream, after which all
+// output is discarded. The error is sticky: it is returned by every following
+// call to Write and Flush until Reset is called.
+func (w *Writer) Flush() error {
+	w.flush()
+	return w.err
+}
+
+// Reset discards all buffered text and clears the error recorded by a failed
+// write to the underlying stream, so that the writer can be used again. The
+// writer's configuration is unaffected.
+func (w *Writer) Reset() {
+	w.err = nil
+	w.header = nil
+	w.pageLines = 0
+	w.chunkBytes = 0
+	w.reset()
+}
+
+// flush formats and outputs the buffered text, recording any error writing
+// to the underlying stream.
+func (w *Writer) flush() {
+	if w.format.flags&SectionFormats != 0 {
+		defer w.ClearAllColumnFormats()
+	}
+	w.throttling = w.throttled()
+	for _, c := range w.children {
+		if err := c.Flush(); err != nil && w.err == nil {
+			w.err = err
+		}
+	}
+	w.prepare()
+	switch {
+	case w.grid():
+		w.writeGrid()
+		w.writeFootnotes()
+		w.header = nil
+		w.reset()
+		return
+
+	case w.flushFormat == FormatHTML:
+		w.writeHTML()
+		w.reset()
+		return
+
+	case w.flushFormat == FormatJSON:
+		w.writeJSON()
+		w.reset()
+		return
+
+	case w.flushFormat == FormatAccessible:
+		w.writeAccessible()
+		w.writeFootnotes()
+		w.reset()
+		return
+
+	case w.flushFormat == FormatTSV:
+		w.writeTSV()
+		w.reset()
+		return
+	}
+
+	w.layout()
+
+	// Format and output the lines.
+	for i, l := range w.lines {
+		last := i == len(w.lines)-1
+		if l.raw.size > 0 {
+			w.linebuf.Write(w.cellText(l.raw))
+			w.emitLine(false)
+			continue
+		}
+		if l.groups != nil {
+			w.writeGroups(l.groups, w.nextTableLine(i))
+			w.emitLine(false)
+			continue
+		}
+
+		w.writeLine(l)
+		if last && l.description.size == 0 {
+			w.trimLastNewline()
+		}
+		w.emitLine(true)
+		if l.description.size > 0 {
+			w.writeDescription(w.cellText(l.description))
+			if last {
+				w.trimLastNewline()
+			}
+			w.emitLine(false)
+		}
+	}
+	w.writeFootnotes()
+
+	w.header = nil
+	w.reset()
+}
+
+// trimLastNewline removes the newline ending the last output line of a flush
+// if the writer's trailing newline is disabled and the line written last was
+// unterminated.
+func (w *Writer) trimLastNewline() {
+	if w.trimNewline && w.unterminated && len(w.footnotes) == 0 {
+		w.linebuf.Truncate(w.linebuf.Len() - len(newline))
+	}
+}
+
+// SetTrailingNewline sets whether a flush outputs a newline after the last
+// line written when it is unterminated. It is enabled by default. Disabling
+// it matches the output of text/tabwriter, so that a table embedded in a
+// larger document isn't followed by a blank line. It only affects the text
+// output format.
+func (w *Writer) SetTrailingNewline(enabled bool) {
+	w.trimNewline = !enabled
+}
+
+// prepare finishes the buffered lines and applies the writer's row
+// transformations to them before they are output.
+func (w *Writer) prepare() {
+	w.unterminated = w.rowPending()
 	if w.cell.size > 0 {
 		w.addCell(w, true)
 	}
@@ -376,59 +1287,324 @@ func (w *Writer) Flush() {
 		w.lines = w.lines[:len(w.lines)-1]
 	}
 
-	// Adjust each line's cell maxwidth values
-	for i := len(w.lines) - 1; i > 0; i-- {
-		curr := &w.lines[i]
+	w.flushFormat = w.currentFormat()
+	w.tracef("flush: %d lines", len(w.lines))
+	w.withoutHeader(w.groupLines)
+	w.pivotLines()
+	w.histogramLines()
+	w.withoutHeader(w.sortLines)
+	w.withoutHeader(w.limitLines)
+	w.statsLines()
+	w.emptyLines()
+	w.minRowLines()
+	w.wideLines()
+	w.markHeader()
+	w.alignColumns()
+	w.truncateColumns()
+	w.alignDecimals()
+	w.leaderLines()
+	w.titleLines()
+}
 
-		if w.padchar == '\t' {
-			// Adjust column widths to hit tab stops.
-			w.tabifyLine(curr)
-		}
+// RenderRows formats the buffered rows like Flush, but returns the padded
+// text of each row's cells instead of writing it to the output. This allows
+// callers that display cells in other ways, such as TUI table widgets, to
+// reuse the writer's alignment. Each output line of a row with wrapped cells
+// is returned as a row of its own. Raw text, group headers, descriptions and
+// annotations are omitted. The buffered rows are discarded.
+func (w *Writer) RenderRows() [][]string {
+	w.prepare()
+	w.layout()
 
-		// Propagate the accumulated maxwidths from the bottom lines upward.
-		prev := &w.lines[i-1]
-		for j, jc := 0, min(len(prev.cells)-1, len(curr.cells)-1); j < jc; j++ {
-			prev.cells[j].maxwidth =
-				max(prev.cells[j].maxwidth, curr.cells[j].maxwidth)
+	var rows [][]string
+	for _, l := range w.lines {
+		if l.raw.size > 0 || l.groups != nil {
+			continue
 		}
-	}
 
-	// Format and output the lines.
-	p := 0
-	for _, l := range w.lines {
-		for j, c := range l.cells {
-			text := w.buf.Bytes()[p : p+c.size]
-			padding := c.maxwidth - c.width
-			w.writeCell(text, padding, w.getFormat(j), c.term)
-			p += c.size
+		height := 1
+		for _, c := range l.cells {
+			height = max(height, len(c.segs))
 		}
-		w.output.Write(newline)
-		if l.description.size > 0 {
-			text := w.buf.Bytes()[p : p+l.description.size]
-			w.writeDescription(text)
-			p += l.description.size
+		for k := 0; k < height; k++ {
+			row := make([]string, len(l.cells))
+			for j, c := range l.cells {
+				text, width := w.cellLine(c, k)
+				format := w.getFormat(j)
+				w.writeCell(text, cellPadding(c, width, format), format, c.term)
+				row[j] = w.linebuf.String()
+				w.linebuf.Reset()
+			}
+			rows = append(rows, row)
 		}
 	}
 
 	w.reset()
+	return rows
 }
 
-// SetColumnFlags sets column-specific format settings for column 'col'.
-func (w *Writer) SetColumnFormat(col int, minwidth int, padding int, flags uint) {
-	if col >= 64 {
-		// Disallow custom formatting on more than 64 columns.
-		return
+// SetOutput redirects the writer's output to a new underlying stream. It may
+// be called between flushes or while text is buffered; buffered text is
+// written to the new stream on the next flush. A nil output discards all
+// subsequent output.
+func (w *Writer) SetOutput(output io.Writer) {
+	if output == nil {
+		output = io.Discard
 	}
+	w.output = output
+}
+
+// SetPadChar sets the character used for padding, which is otherwise set
+// when the writer is created. Like SetOutput, it may be called between
+// flushes or while text is buffered, in which case the buffered text is laid
+// out with the new character on the next flush. This allows a long-lived
+// writer to switch to tab padding, for example, when its output is
+// redirected to a file.
+func (w *Writer) SetPadChar(padchar byte) {
+	w.padchar = padchar
+	w.padbytes = bytes.Repeat([]byte{padchar}, 8)
+}
+
+// SetTabWidth sets the width of a tab in spaces, which is used to align
+// columns padded with tabs. Like SetPadChar, it takes effect for all of the
+// buffered text on the next flush.
+func (w *Writer) SetTabWidth(tabwidth int) {
+	w.tabwidth = tabwidth
+}
+
+// SetColumnFlags sets column-specific format settings for column 'col'. The
+// settings fully replace those of any earlier call for the same column.
+func (w *Writer) SetColumnFormat(col int, minwidth int, padding int, flags uint) {
 	if col >= len(w.formatColumn) {
 		c := make([]format, col+1)
 		copy(c, w.formatColumn)
 		w.formatColumn = c
 	}
-	w.formatColumn[col] = format{minwidth, padding, flags}
-	w.formatColumnBits |= uint64(1) << uint(col)
+	w.formatColumn[col] = format{minwidth: minwidth, padding: padding, flags: flags | specified}
+}
+
+// ClearColumnFormat reverts column 'col' to the writer's default format
+// settings, undoing SetColumnFormat. The column's other settings, such as
+// its wrapping, are kept.
+func (w *Writer) ClearColumnFormat(col int) {
+	if col < len(w.formatColumn) {
+		w.formatColumn[col] = format{}
+	}
+}
+
+// ClearAllColumnFormats reverts every column to the writer's default format
+// settings, so that a long-lived writer can start a new table without the
+// column formats of the previous one.
+func (w *Writer) ClearAllColumnFormats() {
+	w.formatColumn = []format{}
 }
 
 // SetDescriptionFormat sets format settings for description output.
 func (w *Writer) SetDescriptionFormat(indent, wordwrap int) {
 	w.formatDescription = formatDesc{indent, wordwrap}
 }
+
+// SetColumnRenderer sets the renderer used to transform the text of each cell
+// in column 'col' before it is measured and output. A nil renderer restores
+// the column's default behavior.
+func (w *Writer) SetColumnRenderer(col int, r Renderer) {
+	w.setColumn(col).renderer = r
+}
+
+// SetRowFormat sets format settings for rows wider than wrap columns. Such
+// rows are continued on the next line, starting with prefix. A wrap of 0
+// disables row wrapping.
+func (w *Writer) SetRowFormat(wrap int, prefix string) {
+	w.formatRow = formatRow{wrap, prefix}
+}
+
+// SetTerminal sets the terminal the writer's output is displayed on.
+func (w *Writer) SetTerminal(t Terminal) {
+	w.terminal = t
+}
+
+// SetAnnotationOffset sets the number of columns between the end of each row
+// annotation and the right edge of the terminal.
+func (w *Writer) SetAnnotationOffset(offset int) {
+	w.annotationOffset = offset
+}
+
+// SetColumnWrap wraps the text of cells in column 'col' that are wider than
+// width columns onto additional lines, breaking lines according to policy.
+// A width of 0 disables wrapping for the column.
+func (w *Writer) SetColumnWrap(col int, width int, policy BreakPolicy) {
+	w.setColumn(col).wrap = wrap{width, policy}
+}
+
+// SetColumnPadChar sets the character used to pad the cells of column 'col',
+// overriding the writer's padchar, such as '.' for the leader dots of a
+// table of contents. A padchar of 0 restores the writer's padchar. Writers
+// padding with tabs ignore the setting.
+func (w *Writer) SetColumnPadChar(col int, padchar byte) {
+	w.setColumn(col).padchar = padchar
+}
+
+// SetColumnCollapse sets whether the text of cells in column 'col' has its
+// internal runs of whitespace collapsed to single spaces, and its leading and
+// trailing whitespace trimmed, before it is measured. This keeps columns tight
+// when data comes from sloppily formatted sources.
+func (w *Writer) SetColumnCollapse(col int, enabled bool) {
+	w.setColumn(col).collapse = enabled
+}
+
+// SetColumnRedactor sets a function used to redact the text of cells in
+// column 'col' before they are measured and output, so that tables
+// containing secrets can be printed safely. Mask returns a typical redactor.
+// Cells whose text is changed by the redactor are followed by the writer's
+// redaction note, if any. A nil redactor disables redaction for the column.
+func (w *Writer) SetColumnRedactor(col int, r Renderer) {
+	w.setColumn(col).redactor = r
+}
+
+// SetColumnUnit sets the unit of the values in column 'col', such as "ms".
+// The unit is appended to the column's heading in the first row of each
+// flush, so that "LATENCY" is output as "LATENCY (ms)". If strip is true, the
+// unit is removed from the end of the column's other cells before they are
+// measured, so that "12ms" is output as "12" and aligns as a number. An empty
+// unit disables the column's unit.
+func (w *Writer) SetColumnUnit(col int, unit string, strip bool) {
+	c := w.setColumn(col)
+	c.unit, c.stripUnit = unit, strip
+}
+
+// SetRedactionNote sets text appended to every cell changed by a column's
+// redactor, such as " (redacted)", to mark the cell as redacted.
+func (w *Writer) SetRedactionNote(note string) {
+	w.redactionNote = note
+}
+
+// SetColumnMinContent sets whether wrapping column 'col' is sized to fit its
+// longest word (min-content) rather than its longest line (max-content). When
+// enabled, cells are wrapped at the width of the column's longest word, which
+// produces much narrower tables for columns containing prose. It has no
+// effect on columns without wrapping or using BreakNone.
+func (w *Writer) SetColumnMinContent(col int, enabled bool) {
+	w.setColumn(col).minContent = enabled
+}
+
+// SetNormalizer sets a function used to normalize the text of every cell
+// and description before it is measured, rendered and output. Normalizing to
+// NFC, for example with norm.NFC.String from golang.org/x/text/unicode/norm,
+// makes decomposed sequences such as "e" followed by a combining acute accent
+// measure and compare the same as precomposed text. A nil normalizer leaves
+// text unchanged.
+func (w *Writer) SetNormalizer(n Renderer) {
+	w.normalizer = n
+}
+
+// SetBidiIsolation sets whether cells containing right-to-left text are
+// wrapped in Unicode bidi isolate characters (FSI and PDI) on output. This
+// keeps terminals from visually reordering adjacent columns when cells mix
+// right-to-left and left-to-right text. The isolates are not counted in the
+// width of the cells.
+func (w *Writer) SetBidiIsolation(enabled bool) {
+	w.bidiIsolate = enabled
+}
+
+// ReserveColumnWidth reserves space for width columns of text in column 'col',
+// even if no cell in the column is as wide. This keeps a column's width
+// stable across flushes when wider values are known to arrive later.
+func (w *Writer) ReserveColumnWidth(col int, width int) {
+	w.setColumn(col).reserve = width
+}
+
+// SetColumnMaxWidth truncates the text of cells in column 'col' that is
+// wider than width, ending it with the writer's ellipsis, so that a single
+// overlong value can't widen the column. Unlike sizing, the maximum applies
+// to every cell of the column, including the last cells of rows, and takes
+// precedence over wrapping. The export formats FormatHTML, FormatJSON and
+// FormatTSV output cells in full. A width of 0 removes the maximum.
+func (w *Writer) SetColumnMaxWidth(col int, width int) {
+	w.setColumn(col).maxWidth = width
+}
+
+// SetColumnSoftMax sets a soft maximum width for the text of column 'col'.
+// As long as no more than percent percent of the cells in a column are wider
+// than width, the column is sized as if they weren't, and the wider cells are
+// handled according to overflow. Otherwise, the column is sized to fit all of
+// its cells. A width of 0 removes the soft maximum.
+func (w *Writer) SetColumnSoftMax(col int, width, percent int, overflow Overflow) {
+	if width <= 0 {
+		w.SetColumnSizing(col, nil)
+		return
+	}
+	w.SetColumnSizing(col, SoftMax(width, percent))
+	w.SetColumnOverflow(col, overflow)
+}
+
+// SetColumnSizing sets the sizing used to compute the width of column 'col'.
+// Cells wider than the computed width are handled according to the column's
+// overflow setting. A nil sizing restores the default, MaxContent.
+func (w *Writer) SetColumnSizing(col int, sizing Sizing) {
+	w.setColumn(col).sizing = sizing
+}
+
+// SetColumnOverflow sets how cells too wide for column 'col' are output.
+func (w *Writer) SetColumnOverflow(col int, overflow Overflow) {
+	w.setColumn(col).overflow = overflow
+}
+
+// SetPageLength paginates the output, starting a new page whenever the next
+// line wouldn't fit within the given number of output lines. A line's wrapped
+// continuation lines are kept on the same page. A length of 0 disables
+// pagination.
+func (w *Writer) SetPageLength(lines int) {
+	w.formatPage.length = lines
+	w.pageLines = 0
+}
+
+// SetPageBreak sets the sequence output between pages of paginated output,
+// which defaults to a form feed. If header is true, the first line of each
+// flush is repeated at the top of every page that follows.
+func (w *Writer) SetPageBreak(brk string, header bool) {
+	w.formatPage.brk = brk
+	w.formatPage.header = header
+}
+
+// SetEllipsis sets the text that marks the end of truncated cells and the
+// remainder row of LimitRows. It defaults to "…" (U+2026); "..." suits
+// environments that cannot display it. The ellipsis is measured like cell
+// text when cells are truncated.
+func (w *Writer) SetEllipsis(ellipsis string) {
+	w.ellipsis = ellipsis
+}
+
+// SetTitle sets a line of text output above the table of each flush that
+// contains rows. Formats that omit raw text, such as FormatHTML, omit the
+// title as well. An empty title disables it.
+func (w *Writer) SetTitle(title string) {
+	w.title = title
+}
+
+// titleLines inserts the writer's title before the buffered lines.
+func (w *Writer) titleLines() {
+	if w.title == "" || len(w.nextTableLine(-1).cells) == 0 {
+		return
+	}
+	w.addTextToCell([]byte(w.title + "\n"))
+	w.cell.start = w.buf.Len() - w.cell.size
+	title := line{raw: w.cell}
+	w.cell = cell{}
+	w.lines = append([]line{title}, w.lines...)
+}
+
+// SetOutputFormat sets the format in which the writer presents its aligned
+// columns.
+func (w *Writer) SetOutputFormat(f OutputFormat) {
+	w.outputFormat = f
+}
+
+// SetFallbackFormat sets the output format used instead of the writer's
+// output format when its terminal reports that the output is not a TTY, such
+// as FormatTSV, so that output piped to other programs is easy to parse while
+// output shown to people stays aligned. The fallback applies only to
+// terminals implementing TTYReporter, such as those returned by
+// FileTerminal.
+func (w *Writer) SetFallbackFormat(f OutputFormat) {
+	w.fallbackFormat = f
+}
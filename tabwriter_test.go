@@ -1,8 +1,11 @@
 package tabwriter
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	tw "text/tabwriter"
@@ -43,3 +46,669 @@ func TestTabWriter(t *testing.T) {
 	fmt.Fprintln(w2, "123\t12345\t1234567\t123456\t200\t18")
 	w2.Flush()
 }
+
+func TestSetOutput(t *testing.T) {
+	var b1, b2 bytes.Buffer
+	w := NewWriter(&b1, 0, 8, 1, ' ', 0)
+	fmt.Fprint(w, "a\tb\n")
+	w.Flush()
+
+	fmt.Fprint(w, "ccc\td\n")
+	w.SetOutput(&b2)
+	fmt.Fprint(w, "e\tf\n")
+	w.Flush()
+
+	w.SetOutput(nil)
+	fmt.Fprint(w, "g\th\n")
+	w.Flush()
+
+	if got, want := b1.String(), "a b\n"; got != want {
+		t.Errorf("first output: got %q, want %q", got, want)
+	}
+	if got, want := b2.String(), "ccc d\ne   f\n"; got != want {
+		t.Errorf("second output: got %q, want %q", got, want)
+	}
+}
+
+func TestClone(t *testing.T) {
+	var b1, b2 bytes.Buffer
+	w1 := NewWriter(&b1, 0, 8, 1, '.', 0)
+	w1.SetColumnFormat(1, 0, 1, AlignRight)
+	w1.SetDescriptionFormat(2, 72)
+	fmt.Fprint(w1, "pending\t1\t")
+
+	w2 := w1.Clone(&b2)
+	w1.SetColumnFormat(1, 0, 1, 0)
+	fmt.Fprint(w2, "a\t1\tx\rdesc\n")
+	fmt.Fprint(w2, "b\t100\ty\n")
+	w2.Flush()
+
+	want := "a...1.x\n" +
+		"..desc\n" +
+		"b.100.y\n"
+	if b2.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b2.String(), want)
+	}
+}
+
+func TestRowFormat(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetRowFormat(16, "  > ")
+	fmt.Fprint(w, "eth0\tUP\t10.0.0.1/24\tfe80::1/64\n")
+	fmt.Fprint(w, "lo\tUNKNOWN\t127.0.0.1/8\t::1/128\n")
+	w.Flush()
+
+	want := "eth0 UP\n" +
+		"  > 10.0.0.1/24\n" +
+		"  > fe80::1/64\n" +
+		"lo   UNKNOWN\n" +
+		"  > 127.0.0.1/8\n" +
+		"  > ::1/128\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteRaw(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	fmt.Fprint(w, "NAME\tSTATUS\tAGE\n")
+	w.WriteRaw([]byte("# group 1\n"))
+	fmt.Fprint(w, "web\tRunning\t2d\n")
+	fmt.Fprint(w, "db\tPending")
+	w.WriteRaw([]byte("# group 2\n"))
+	fmt.Fprint(w, "worker-long\tRunning\t5m\n")
+	w.Flush()
+
+	want := "NAME        STATUS  AGE\n" +
+		"# group 1\n" +
+		"web         Running 2d\n" +
+		"db          Pending\n" +
+		"# group 2\n" +
+		"worker-long Running 5m\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSetBlankLineSpacers(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetBlankLineSpacers(true)
+	fmt.Fprint(w, "web\tRunning\t2d\n")
+	fmt.Fprint(w, "\n")
+	fmt.Fprint(w, "worker-long\tPending\t5m\n")
+	w.Flush()
+
+	want := "web         Running 2d\n" +
+		"\n" +
+		"worker-long Pending 5m\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetTerminal(FixedWidth(30))
+	w.SetAnnotationOffset(1)
+	fmt.Fprint(w, "build\tcompiler\t")
+	w.Annotate("[OK]")
+	fmt.Fprint(w, "\n")
+	fmt.Fprint(w, "test\tunit\t1.2s\n")
+	w.Annotate("[FAILED]")
+	fmt.Fprint(w, "lint\tvery long description of a step\n")
+	w.Flush()
+
+	want := "build compiler           [OK]\n" +
+		"test  unit 1.2s\n" +
+		"lint  very long description of a step [FAILED]\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestReserveColumnWidth(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.ReserveColumnWidth(1, len("COMPLETED"))
+	fmt.Fprint(w, "job-1\tRUNNING\t10%\n")
+	w.Flush()
+	fmt.Fprint(w, "job-1\tCOMPLETED\t100%\n")
+	w.Flush()
+
+	want := "job-1 RUNNING   10%\n" +
+		"job-1 COMPLETED 100%\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestPageLength(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	if w.formatPage.brk != "\f" {
+		t.Errorf("default page break is %q, want form feed", w.formatPage.brk)
+	}
+	w.SetPageLength(3)
+	w.SetPageBreak("\f", true)
+	fmt.Fprint(w, "NAME\tSIZE\n")
+	for _, s := range []string{"a\t1", "b\t22", "c\t333", "d\t4444", "e\t5"} {
+		fmt.Fprint(w, s+"\n")
+	}
+	w.Flush()
+
+	want := "NAME SIZE\n" +
+		"a    1\n" +
+		"b    22\n" +
+		"\f" +
+		"NAME SIZE\n" +
+		"c    333\n" +
+		"d    4444\n" +
+		"\f" +
+		"NAME SIZE\n" +
+		"e    5\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestSetNormalizer(t *testing.T) {
+	// Compose the only decomposed sequence used by the test.
+	nfc := strings.NewReplacer("e\u0301", "\u00e9").Replace
+
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetNormalizer(nfc)
+	fmt.Fprint(w, "cafe\u0301\tok\n")
+	fmt.Fprint(w, "cafe\tok\n")
+	w.Flush()
+
+	want := "caf\u00e9 ok\n" +
+		"cafe ok\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSetBidiIsolation(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetBidiIsolation(true)
+	fmt.Fprint(w, "שלום\tworld\n")
+	fmt.Fprint(w, "hello\tworld\n")
+	w.Flush()
+
+	want := "\u2068שלום\u2069  world\n" +
+		"hello world\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestSetColumnCollapse(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnCollapse(0, true)
+	fmt.Fprint(w, "  John   Smith \tadmin\n")
+	fmt.Fprint(w, "Jane  Doe\tuser\n")
+	w.Flush()
+
+	want := "John Smith admin\n" +
+		"Jane Doe   user\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestSetColumnUnit(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, AlignRight)
+	w.SetColumnUnit(1, "ms", true)
+	fmt.Fprint(w, "HOST\tLATENCY\tSTATUS\n")
+	fmt.Fprint(w, "alpha\t12ms\tok\n")
+	fmt.Fprint(w, "beta\t1450 ms\tslow\n")
+	w.Flush()
+
+	want := "HOST  LATENCY (ms) STATUS\n" +
+		"alpha           12 ok\n" +
+		"beta          1450 slow\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+// failWriter accepts n writes and fails all writes that follow.
+type failWriter struct {
+	n   int
+	err error
+}
+
+func (f *failWriter) Write(p []byte) (int, error) {
+	if f.n == 0 {
+		return 0, f.err
+	}
+	f.n--
+	return len(p), nil
+}
+
+func TestFlushError(t *testing.T) {
+	failure := errors.New("disk full")
+	out := &failWriter{n: 1, err: failure}
+	w := NewWriter(out, 0, 8, 1, ' ', 0)
+	fmt.Fprint(w, "a\tb\nc\td\n")
+	if err := w.Flush(); err != failure {
+		t.Errorf("Flush: got error %v, want %v", err, failure)
+	}
+	if out.n != 0 {
+		t.Errorf("%d writes left unused", out.n)
+	}
+
+	// The error is sticky until the writer is reset.
+	out.n = 2
+	if n, err := w.Write([]byte("e\tf\n")); n != 0 || err != failure {
+		t.Errorf("Write: got %d, %v, want 0, %v", n, err, failure)
+	}
+	if err := w.Flush(); err != failure {
+		t.Errorf("Flush: got error %v, want %v", err, failure)
+	}
+
+	w.Reset()
+	fmt.Fprint(w, "e\tf\n")
+	if _, err := w.Write([]byte("g\th\f")); err != nil {
+		t.Errorf("Write: got error %v", err)
+	}
+	fmt.Fprint(w, "i\tj\n")
+	if _, err := w.Write([]byte("\n")); err != failure {
+		t.Errorf("Write: got error %v, want %v", err, failure)
+	}
+	if _, err := w.Write([]byte("k\n")); err != failure {
+		t.Errorf("Write: got error %v, want %v", err, failure)
+	}
+}
+
+func TestAlignCenter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, '.', 0)
+	w.SetColumnFormat(1, 0, 1, AlignCenter)
+	fmt.Fprint(w, "region\tQ1\ttotal\n")
+	fmt.Fprint(w, "north\t1200\t5\n")
+	fmt.Fprint(w, "south\t35\t6\n")
+	w.Flush()
+
+	want := "region..Q1..total\n" +
+		"north..1200.5\n" +
+		"south...35..6\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	w = NewWriter(&b, 0, 8, 1, ' ', AlignCenter)
+	w.SetOutputFormat(FormatMarkdown)
+	fmt.Fprint(w, "name\tid\nx\t1234\n")
+	w.Flush()
+
+	want = "| name |  id  |\n" +
+		"|:----:|:----:|\n" +
+		"|  x   | 1234 |\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestAlignDecimal(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, AlignDecimal)
+	fmt.Fprint(w, "ITEM\tAMOUNT\tNOTE\n")
+	fmt.Fprint(w, "rent\t1234.25\tmonthly\n")
+	fmt.Fprint(w, "coffee\t3.5\tdaily\n")
+	fmt.Fprint(w, "refund\t-12\t\u2014\n")
+	fmt.Fprint(w, "tax\tn/a\t\u2014\n")
+	w.Flush()
+
+	want := "ITEM    AMOUNT NOTE\n" +
+		"rent   1234.25 monthly\n" +
+		"coffee    3.5  daily\n" +
+		"refund  -12    \u2014\n" +
+		"tax        n/a \u2014\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	w.SetDecimalSeparator(',')
+	fmt.Fprint(w, "a\t1.234,5\tx\n")
+	fmt.Fprint(w, "b\t0,125\tx\n")
+	w.Flush()
+
+	want = "a 1.234,5   x\n" +
+		"b     0,125 x\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestSetColumnPadChar(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnPadChar(1, '.')
+	fmt.Fprint(w, "1\tIntroduction\t1\n")
+	fmt.Fprint(w, "2\tGetting started\t5\n")
+	fmt.Fprint(w, "10\tReference\t42\n")
+	w.Flush()
+
+	want := "1  Introduction....1\n" +
+		"2  Getting started.5\n" +
+		"10 Reference.......42\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	w.SetColumnPadChar(1, '\t')
+	if err := w.Validate(); err == nil {
+		t.Error("Validate accepted a tab column padchar")
+	}
+}
+
+func TestPadCell(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	fmt.Fprint(w, "name\tcpu")
+	w.PadCell(3)
+	fmt.Fprint(w, "\tread\twrite\n")
+	fmt.Fprint(w, "web\t12%\t1.2MB\t300KB\n")
+	w.Flush()
+
+	want := "name cpu    read  write\n" +
+		"web  12%    1.2MB 300KB\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestAlignNumeric(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, AlignNumeric)
+	fmt.Fprint(w, "NAME\tSIZE\tNOTE\n")
+	fmt.Fprint(w, "disk\t1,123 MiB\tfull\n")
+	fmt.Fprint(w, "cache\t45 ms*\thot\n")
+	fmt.Fprint(w, "swap\t1.5 GiB\tidle\n")
+	fmt.Fprint(w, "tmp\tn/a\t\u2014\n")
+	w.Flush()
+
+	want := "NAME         SIZE NOTE\n" +
+		"disk  1,123 MiB   full\n" +
+		"cache    45 ms*   hot\n" +
+		"swap      1.5 GiB idle\n" +
+		"tmp           n/a \u2014\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteRow(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.WriteRow("NAME", "ADDRESS", "ZIP")
+	w.WriteRow("Ann", "12 Main St\nApt 4\r\nSpringfield", "12345")
+	w.WriteRow("Bob", "1 Elm\tRd", "54321")
+	w.Flush()
+
+	want := "NAME ADDRESS     ZIP\n" +
+		"Ann  12 Main St  12345\n" +
+		"     Apt 4\n" +
+		"     Springfield\n" +
+		"Bob  1 Elm Rd    54321\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	w.SetOutputFormat(FormatBorder)
+	w.SetColumnWrap(1, 6, BreakWord)
+	w.WriteRow("a", "one two\nthree", "x")
+	w.Flush()
+
+	want = "┌───┬───────┬───┐\n" +
+		"│ a │ one   │ x │\n" +
+		"│   │ two   │   │\n" +
+		"│   │ three │   │\n" +
+		"└───┴───────┴───┘\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteBlock(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 4, 1, ' ', 0)
+	w.SetColumnWrap(1, 6, BreakWord)
+	w.SetColumnCollapse(1, true)
+	fmt.Fprint(w, "loop\t")
+	w.WriteBlock("for {\n\tstep()\n}")
+	fmt.Fprint(w, "\trepeats\tforever\n")
+	fmt.Fprint(w, "nop\t\tdoes nothing\tat all\n")
+	w.Flush()
+
+	want := "loop for {      repeats      forever\n" +
+		"         step()\n" +
+		"     }\n" +
+		"nop             does nothing at all\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestClearColumnFormat(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(0, 0, 1, AlignRight)
+	w.SetColumnFormat(1, 0, 3, 0)
+	w.SetColumnFormat(1, 0, 2, 0)
+	fmt.Fprint(w, "a\tb\tc\n")
+	fmt.Fprint(w, "aaa\tbbb\tc\n")
+	w.Flush()
+
+	w.ClearColumnFormat(1)
+	fmt.Fprint(w, "a\tb\tc\n")
+	w.Flush()
+
+	w.ClearAllColumnFormats()
+	fmt.Fprint(w, "a\tb\tc\n")
+	fmt.Fprint(w, "aaa\tbbb\tc\n")
+	w.Flush()
+
+	want := "  a b    c\n" +
+		"aaa bbb  c\n" +
+		"a b c\n" +
+		"a   b   c\n" +
+		"aaa bbb c\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	// Reinitializing a writer discards its column formats.
+	w.SetColumnFormat(5, 0, 1, AlignRight)
+	w.Init(&b, 0, 8, 1, ' ', 0)
+	if m, p, f := w.ColumnFormat(5); m != 0 || p != 1 || f != 0 {
+		t.Errorf("ColumnFormat after Init: got %d, %d, %d", m, p, f)
+	}
+}
+
+func TestSectionFormats(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', SectionFormats)
+	w.SetColumnFormat(1, 0, 1, AlignRight)
+	fmt.Fprint(w, "disk\t10\tGB\n")
+	fmt.Fprint(w, "mem\t512\tMB\n")
+	fmt.Fprint(w, "\f")
+	fmt.Fprint(w, "key\tvalue\tnote\n")
+	fmt.Fprint(w, "k\tv\tn\n")
+	w.Flush()
+
+	want := "disk  10 GB\n" +
+		"mem  512 MB\n" +
+		"key value note\n" +
+		"k   v     n\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestColumnFormatWide(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(100, 0, 1, AlignRight)
+	w.SetColumnFormat(101, 0, 3, 0)
+	row := func(last ...string) {
+		fmt.Fprint(w, strings.Repeat("x\t", 100)+strings.Join(last, "\t")+"\n")
+	}
+	row("1", "a", "end")
+	row("100", "b", "end")
+	w.Flush()
+
+	prefix := strings.Repeat("x ", 100)
+	want := prefix + "  1 a   end\n" +
+		prefix + "100 b   end\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+	if _, p, f := w.ColumnFormat(101); p != 3 || f != 0 {
+		t.Errorf("ColumnFormat: got padding %d, flags %d", p, f)
+	}
+}
+
+func TestDiscardEmptyColumns(t *testing.T) {
+	tests := []struct {
+		padchar byte
+		flags   uint
+		want    string
+	}{
+		{
+			' ', 0,
+			"name tag size\n" +
+				"a.go  120\n" +
+				"b.go  4096\n",
+		},
+		{
+			' ', DiscardEmptyColumns,
+			"name tag size\n" +
+				"a.go 120\n" +
+				"b.go 4096\n",
+		},
+		{
+			'\t', DiscardEmptyColumns,
+			"name\ttag\tsize\n" +
+				"a.go\t120\n" +
+				"b.go\t4096\n",
+		},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, test.padchar, test.flags)
+		fmt.Fprint(w, "name\ttag\tsize\n\f")
+		fmt.Fprint(w, "a.go\t\t120\n")
+		fmt.Fprint(w, "b.go\t\t4096\n")
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("flags %d: got:\n%q\nwant:\n%q", test.flags, b.String(), test.want)
+		}
+	}
+}
+
+func TestDebug(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', Debug)
+	w.SetColumnFormat(1, 0, 1, AlignRight)
+	w.SetColumnWrap(2, 4, BreakWord)
+	fmt.Fprint(w, "a\tb\tc\n")
+	fmt.Fprint(w, "aaa\t12345\tcc dd\td\n")
+	w.Flush()
+
+	want := "a   |    b |c\n" +
+		"aaa |12345 |cc |d\n" +
+		"    |      |dd\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestTabIndent(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 4, 4, 1, '.', TabIndent)
+	fmt.Fprint(w, "\tif\tx\n")
+	fmt.Fprint(w, "\t\tbody\tyyy\n")
+	fmt.Fprint(w, "end\t\tz\n")
+	w.Flush()
+
+	want := "\tif..x\n" +
+		"\t\tbody.yyy\n" +
+		"end.....z\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestSetTrailingNewline(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetTrailingNewline(false)
+	fmt.Fprint(w, "a\tb\nccc\td")
+	w.Flush()
+	fmt.Fprint(w, "|x\n")
+	w.Flush()
+
+	want := "a   b\n" +
+		"ccc d|x\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		flags uint
+		want  string
+	}{
+		{0, "a\xff\tb\xff.c\n" +
+			"dd..e\n"},
+		{StripEscape, "a\tb.c\n" +
+			"dd..e\n"},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, '.', test.flags)
+		fmt.Fprint(w, "a\xff\tb\xff\tc\n")
+		fmt.Fprint(w, "dd\te\n")
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("flags %d: got:\n%q\nwant:\n%q", test.flags, b.String(), test.want)
+		}
+	}
+}
+
+func TestFilterHTML(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, '.', FilterHTML)
+	fmt.Fprint(w, "<b>name</b>\tsize\n")
+	fmt.Fprint(w, "a&amp;b\t12\n")
+	fmt.Fprint(w, "<a href=\"x\ty\">link</a>\t3\n")
+	w.Flush()
+
+	want := "<b>name</b>.size\n" +
+		"a&amp;b..12\n" +
+		"<a href=\"x\ty\">link</a>.3\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
@@ -0,0 +1,74 @@
+// Package tabwritertest provides utilities for testing the output of
+// tabwriter.Writers.
+package tabwritertest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/beevik/tabwriter"
+)
+
+// Characters that connect a grid's vertical separators to the line below
+// or the line above, in any of the preset border styles or in markdown.
+var down, up = separators()
+
+// separators returns the characters of the preset border styles that
+// connect to the line below and those that connect to the line above.
+func separators() (down, up string) {
+	styles := []tabwriter.BorderStyle{
+		tabwriter.BorderASCII,
+		tabwriter.BorderLight,
+		tabwriter.BorderHeavy,
+		tabwriter.BorderDouble,
+		tabwriter.BorderRounded,
+	}
+	for _, s := range styles {
+		sides := s.Vertical + s.LeftJunction + s.RightJunction + s.Cross
+		down += sides + s.TopLeft + s.TopRight + s.TopJunction
+		up += sides + s.BottomLeft + s.BottomRight + s.BottomJunction
+	}
+	return down, up
+}
+
+// CheckRectangular returns an error unless the lines of grid output text,
+// such as a table written in FormatBorder or FormatMarkdown, form a
+// rectangle: every line must have the same display width, and every vertical
+// separator must continue into the line below it. Wrapped rows whose
+// continuation lines omit or misplace a separator fail the check. Rows whose
+// cells span several columns, such as group headers, pass only at the top of
+// a grid, where the columns they span start. Grids may be drawn in any of
+// the preset border styles, and widths are measured like tabwriter.Writers
+// measure them, so that East Asian wide characters occupy two columns.
+func CheckRectangular(text string) error {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	grid := make([]map[int]rune, len(lines))
+	for i, l := range lines {
+		if width, want := tabwriter.DisplayWidth(l), tabwriter.DisplayWidth(lines[0]); width != want {
+			return fmt.Errorf("line %d is %d columns wide, want %d", i+1, width, want)
+		}
+		grid[i] = columns(l)
+	}
+
+	for i := 0; i+1 < len(grid); i++ {
+		for p, r := range grid[i] {
+			if strings.ContainsRune(down, r) && !strings.ContainsRune(up, grid[i+1][p]) {
+				return fmt.Errorf("line %d: separator in column %d does not continue into the next line", i+1, p+1)
+			}
+		}
+	}
+	return nil
+}
+
+// columns returns the first rune output in each column of line l, keyed by
+// the column's position.
+func columns(l string) map[int]rune {
+	cols := make(map[int]rune)
+	for i, r := range l {
+		p := tabwriter.DisplayWidth(l[:i])
+		if _, ok := cols[p]; !ok {
+			cols[p] = r
+		}
+	}
+	return cols
+}
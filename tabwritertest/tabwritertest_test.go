@@ -0,0 +1,69 @@
+package tabwritertest
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/beevik/tabwriter"
+)
+
+func TestCheckRectangular(t *testing.T) {
+	for _, format := range []tabwriter.OutputFormat{tabwriter.FormatBorder, tabwriter.FormatMarkdown} {
+		var b bytes.Buffer
+		w := tabwriter.NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.SetOutputFormat(format)
+		w.SetColumnWrap(1, 10, tabwriter.BreakWord)
+		w.SetColumnWrap(2, 6, tabwriter.BreakHard)
+		w.SetColumnFormat(3, 0, 1, tabwriter.AlignRight)
+		w.WriteGroupHeader(tabwriter.Group{Title: "item", Span: 2}, tabwriter.Group{Title: "result", Span: 2})
+		fmt.Fprint(w, "id\tdescription\tcode\tn\n")
+		fmt.Fprint(w, "1\tthe quick brown fox jumps\tabcdefghijklmno\t12\n")
+		fmt.Fprint(w, "2\tshort\tx\t3\n")
+		fmt.Fprint(w, "3\tx\n")
+		fmt.Fprint(w, "4\tagain a long one here\tz\t1\n")
+		w.Flush()
+
+		if err := CheckRectangular(b.String()); err != nil {
+			t.Errorf("format %d: %v\n%s", format, err, b.String())
+		}
+	}
+
+	styles := []tabwriter.BorderStyle{
+		tabwriter.BorderASCII,
+		tabwriter.BorderLight,
+		tabwriter.BorderHeavy,
+		tabwriter.BorderDouble,
+		tabwriter.BorderRounded,
+	}
+	for _, style := range styles {
+		var b bytes.Buffer
+		w := tabwriter.NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.SetOutputFormat(tabwriter.FormatBorder)
+		w.SetBorderStyle(style)
+		w.SetColumnWrap(1, 6, tabwriter.BreakHard)
+		fmt.Fprint(w, "id\tname\tcity\n")
+		fmt.Fprint(w, "1\t日本語のテキスト\t東京\n")
+		fmt.Fprint(w, "2\tx\ty\n")
+		w.Flush()
+
+		if err := CheckRectangular(b.String()); err != nil {
+			t.Errorf("style %q: %v\n%s", style.Cross, err, b.String())
+		}
+	}
+
+	bad := []string{
+		"┌──┬──┐\n│ a│ b│\n│ c  d│\n└──┴──┘\n",
+		"┌──┬──┐\n│ a│ b│\n│ c│ d \n└──┴──┘\n",
+		"┌──┬──┐\n│ a│ b│\n│ c│ d│\n└─────┘\n",
+		"┌──┬──┐\n│ a│ b│\n│ c│ d│ e\n└──┴──┘\n",
+		"┏━━┳━━┓\n┃ a┃ b┃\n┃ c  d┃\n┗━━┻━━┛\n",
+		"+--+--+\n| a| b|\n| c  d|\n+--+--+\n",
+		"┌──┬──┐\n│日│ b│\n│ 日 │ d│\n└──┴──┘\n",
+	}
+	for _, text := range bad {
+		if CheckRectangular(text) == nil {
+			t.Errorf("expected an error for:\n%s", text)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package tabwriter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Template captures a complete table definition that can be applied to
+// many writers, so that standard report layouts can be shared as code or,
+// since templates can be encoded as JSON, as configuration.
+type Template struct {
+	Title   string           `json:"title,omitempty"`   // line output above each table
+	Format  OutputFormat     `json:"format"`            // output format
+	Header  bool             `json:"header,omitempty"`  // treat the first row as the header
+	Columns []TemplateColumn `json:"columns,omitempty"` // settings of each column, in order
+}
+
+// A TemplateColumn describes the settings of one column of a Template.
+type TemplateColumn struct {
+	Name     string `json:"name,omitempty"`     // name of the column
+	Align    string `json:"align,omitempty"`    // "left" (default), "right", "center", "decimal" or "numeric"
+	MinWidth int    `json:"minWidth,omitempty"` // minimal cell width including padding
+	Wrap     int    `json:"wrap,omitempty"`     // width at which text is word-wrapped
+	Unit     string `json:"unit,omitempty"`     // unit shown in the heading and stripped from values
+	Renderer string `json:"renderer,omitempty"` // renderer transforming the cells
+}
+
+// Apply configures w according to the template. Column names are registered
+// with SetHeader. Renderers are named by a spec: "bool" renders Bool("✓",
+// "✗"), "hex" HexEscape, "mask:N" Mask(N), "sparkline:N" Sparkline(N) and
+// "bar:N:MAX" Bar(N, MAX). Apply returns an error if the template contains an
+// unknown alignment or renderer, in which case w is left unchanged.
+func (t *Template) Apply(w *Writer) error {
+	renderers := make([]Renderer, len(t.Columns))
+	for col, c := range t.Columns {
+		if c.Align != "" && c.Align != "left" && c.Align != "right" && c.Align != "center" && c.Align != "decimal" && c.Align != "numeric" {
+			return fmt.Errorf("tabwriter: column %d: unknown alignment %q", col, c.Align)
+		}
+		if c.Renderer != "" {
+			r, err := parseRenderer(c.Renderer)
+			if err != nil {
+				return fmt.Errorf("tabwriter: column %d: %v", col, err)
+			}
+			renderers[col] = r
+		}
+	}
+
+	w.SetTitle(t.Title)
+	w.SetOutputFormat(t.Format)
+	w.UseFirstRowAsHeader(t.Header)
+
+	names := make([]string, len(t.Columns))
+	for col, c := range t.Columns {
+		names[col] = c.Name
+
+		var flags uint
+		switch c.Align {
+		case "right":
+			flags = AlignRight
+		case "center":
+			flags = AlignCenter
+		case "decimal":
+			flags = AlignDecimal
+		case "numeric":
+			flags = AlignNumeric
+		}
+		w.SetColumnFormat(col, c.MinWidth, w.format.padding, flags)
+		if c.Wrap > 0 {
+			w.SetColumnWrap(col, c.Wrap, BreakWord)
+		}
+		if c.Unit != "" {
+			w.SetColumnUnit(col, c.Unit, true)
+		}
+		w.SetColumnRenderer(col, renderers[col])
+	}
+	w.SetHeader(names...)
+	return nil
+}
+
+// parseRenderer returns the renderer described by spec.
+func parseRenderer(spec string) (Renderer, error) {
+	name, args, _ := strings.Cut(spec, ":")
+	var nums []float64
+	if args != "" {
+		for _, a := range strings.Split(args, ":") {
+			n, err := strconv.ParseFloat(a, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid renderer %q", spec)
+			}
+			nums = append(nums, n)
+		}
+	}
+
+	switch {
+	case name == "bool" && len(nums) == 0:
+		return Bool("✓", "✗"), nil
+	case name == "hex" && len(nums) == 0:
+		return HexEscape, nil
+	case name == "mask" && len(nums) == 1:
+		return Mask(int(nums[0])), nil
+	case name == "sparkline" && len(nums) == 1:
+		return Sparkline(int(nums[0])), nil
+	case name == "bar" && len(nums) == 2:
+		return Bar(int(nums[0]), nums[1]), nil
+	}
+	return nil, fmt.Errorf("invalid renderer %q", spec)
+}
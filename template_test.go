@@ -0,0 +1,59 @@
+package tabwriter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	config := `{
+		"title": "Disk usage",
+		"format": "border",
+		"header": true,
+		"columns": [
+			{"name": "MOUNT"},
+			{"name": "USED", "align": "right", "unit": "GB"},
+			{"name": "FULL", "renderer": "bar:4:100"}
+		]
+	}`
+	var tmpl Template
+	if err := json.Unmarshal([]byte(config), &tmpl); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	if err := tmpl.Apply(w); err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprint(w, "MOUNT\tUSED\tFULL\n")
+	fmt.Fprint(w, "/\t41GB\t50\n")
+	fmt.Fprint(w, "/home\t312GB\t100\n")
+	w.Flush()
+
+	want := "Disk usage\n" +
+		"┌───────┬───────────┬──────┐\n" +
+		"│ MOUNT │ USED (GB) │ FULL │\n" +
+		"│ /     │        41 │ ██   │\n" +
+		"│ /home │       312 │ ████ │\n" +
+		"└───────┴───────────┴──────┘\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	out, err := json.Marshal(tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again Template
+	if err := json.Unmarshal(out, &again); err != nil || again.Format != FormatBorder || len(again.Columns) != 3 {
+		t.Errorf("round trip: got %+v, %v", again, err)
+	}
+
+	bad := Template{Columns: []TemplateColumn{{Renderer: "rainbow"}}}
+	if err := bad.Apply(w); err == nil {
+		t.Error("unknown renderer: got no error")
+	}
+}
@@ -0,0 +1,27 @@
+package tabwriter
+
+// A Terminal describes the display device that a Writer's output is shown
+// on. Writers use it to lay out output relative to the edges of the display.
+type Terminal interface {
+	// Width returns the number of columns the terminal displays, or 0 if the
+	// width is unknown.
+	Width() int
+}
+
+// A TTYReporter is a Terminal that can report whether the output it
+// describes is an interactive terminal rather than, for example, a pipe or
+// file.
+type TTYReporter interface {
+	Terminal
+
+	// IsTerminal returns true if the output is an interactive terminal.
+	IsTerminal() bool
+}
+
+// FixedWidth is a Terminal with a constant width.
+type FixedWidth int
+
+// Width returns the terminal's width in columns.
+func (t FixedWidth) Width() int {
+	return int(t)
+}
@@ -0,0 +1,39 @@
+//go:build !js && !wasip1
+
+package tabwriter
+
+import (
+	"os"
+	"strconv"
+)
+
+// FileTerminal returns a Terminal describing the terminal device that f
+// refers to. Its width is queried each time it is needed, so that it follows
+// the terminal as it is resized. If the width can't be queried from the
+// device, it is taken from the COLUMNS environment variable.
+//
+// FileTerminal is not available when building for js/wasm or wasip1.
+func FileTerminal(f *os.File) Terminal {
+	return fileTerminal{f}
+}
+
+type fileTerminal struct {
+	f *os.File
+}
+
+// IsTerminal returns true if the file is a character device, such as a
+// terminal, rather than a pipe or regular file.
+func (t fileTerminal) IsTerminal() bool {
+	fi, err := t.f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// envWidth returns the terminal width given by the COLUMNS environment
+// variable, or 0 if it is not set.
+func envWidth() int {
+	width, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || width < 0 {
+		return 0
+	}
+	return width
+}
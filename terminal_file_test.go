@@ -0,0 +1,30 @@
+//go:build !js && !wasip1
+
+package tabwriter
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFileTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	t.Setenv("COLUMNS", "93")
+	if width := FileTerminal(f).Width(); width != 93 {
+		t.Errorf("Width() = %d, want 93", width)
+	}
+
+	t.Setenv("COLUMNS", "")
+	if width := FileTerminal(f).Width(); width != 0 {
+		t.Errorf("Width() = %d, want 0", width)
+	}
+
+	if FileTerminal(f).(TTYReporter).IsTerminal() {
+		t.Error("IsTerminal() = true for a regular file, want false")
+	}
+}
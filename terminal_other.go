@@ -0,0 +1,7 @@
+//go:build !js && !wasip1 && !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package tabwriter
+
+func (t fileTerminal) Width() int {
+	return envWidth()
+}
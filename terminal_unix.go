@@ -0,0 +1,21 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package tabwriter
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+func (t fileTerminal) Width() int {
+	var ws struct {
+		row, col       uint16
+		xpixel, ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, t.f.Fd(),
+		uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 || ws.col == 0 {
+		return envWidth()
+	}
+	return int(ws.col)
+}
@@ -0,0 +1,83 @@
+package tabwriter
+
+import (
+	"context"
+	"time"
+)
+
+// throttle describes how fast output to an interactive terminal is written.
+type throttle struct {
+	size  int           // bytes written between pauses (0 = unthrottled)
+	delay time.Duration // length of each pause
+}
+
+// SetOutputThrottle limits how fast the writer outputs text to an
+// interactive terminal: after every size bytes written to the underlying
+// stream, it pauses for delay. This keeps a terminal showing a huge table
+// responsive, so that an interrupt from the user, such as Ctrl-C, or the
+// cancellation of FlushContext takes effect promptly. Throttling only
+// applies when the writer's terminal reports that the output is an
+// interactive terminal, as those returned by FileTerminal do; output to
+// pipes and files is written at full speed. A size of 0 disables
+// throttling.
+func (w *Writer) SetOutputThrottle(size int, delay time.Duration) {
+	w.throttle = throttle{size, delay}
+	w.chunkBytes = 0
+}
+
+// FlushContext is like Flush, but stops writing to the underlying stream
+// once ctx is done and returns ctx's error. Cancellation takes effect
+// between output lines, or during the pauses of throttled output. The rest
+// of the buffered text is discarded, and like a failed write, the error is
+// returned by every following call to Write and Flush until Reset is called.
+func (w *Writer) FlushContext(ctx context.Context) error {
+	w.ctx = ctx
+	defer func() {
+		w.ctx = nil
+	}()
+	return w.Flush()
+}
+
+// throttled returns true if the output of the next flush is throttled.
+func (w *Writer) throttled() bool {
+	if w.throttle.size <= 0 {
+		return false
+	}
+	t, ok := w.terminal.(TTYReporter)
+	return ok && t.IsTerminal()
+}
+
+// writeThrottled writes text to the underlying stream, pausing after every
+// chunk of the throttle's size.
+func (w *Writer) writeThrottled(text []byte) {
+	for len(text) > 0 && w.err == nil {
+		n := min(len(text), max(w.throttle.size-w.chunkBytes, 1))
+		if _, err := w.output.Write(text[:n]); err != nil {
+			w.err = err
+			return
+		}
+		text = text[n:]
+		w.chunkBytes += n
+		if w.chunkBytes >= w.throttle.size {
+			w.chunkBytes = 0
+			w.pause()
+		}
+	}
+}
+
+// pause waits for the throttle's delay, or until the context of the current
+// flush is done.
+func (w *Writer) pause() {
+	if w.ctx == nil {
+		time.Sleep(w.throttle.delay)
+		return
+	}
+
+	t := time.NewTimer(w.throttle.delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-w.ctx.Done():
+		w.err = w.ctx.Err()
+	}
+}
@@ -0,0 +1,108 @@
+package tabwriter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// tty is a Terminal describing an interactive terminal.
+type tty struct{}
+
+func (tty) Width() int       { return 80 }
+func (tty) IsTerminal() bool { return true }
+
+// chunkWriter records the size of each write and calls fn after it.
+type chunkWriter struct {
+	bytes.Buffer
+	sizes []int
+	fn    func()
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	c.sizes = append(c.sizes, len(p))
+	if c.fn != nil {
+		c.fn()
+	}
+	return c.Buffer.Write(p)
+}
+
+func TestOutputThrottle(t *testing.T) {
+	var out chunkWriter
+	w := NewWriter(&out, 0, 8, 1, ' ', 0)
+	w.SetTerminal(tty{})
+	w.SetOutputThrottle(4, time.Microsecond)
+	fmt.Fprint(w, "alpha\t1\nbeta\t2\n")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if want := "alpha 1\nbeta  2\n"; out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+	if want := []int{4, 4, 4, 4}; fmt.Sprint(out.sizes) != fmt.Sprint(want) {
+		t.Errorf("write sizes: got %v, want %v", out.sizes, want)
+	}
+
+	// Output to pipes is not throttled.
+	out.Reset()
+	out.sizes = nil
+	w.SetTerminal(pipe{})
+	fmt.Fprint(w, "alpha\t1\nbeta\t2\n")
+	w.Flush()
+	if want := []int{8, 8}; fmt.Sprint(out.sizes) != fmt.Sprint(want) {
+		t.Errorf("pipe write sizes: got %v, want %v", out.sizes, want)
+	}
+}
+
+func TestOutputThrottleShrink(t *testing.T) {
+	var out chunkWriter
+	w := NewWriter(&out, 0, 8, 1, ' ', 0)
+	w.SetTerminal(tty{})
+	w.SetOutputThrottle(100, time.Microsecond)
+	fmt.Fprint(w, "alpha\t1\n")
+	w.Flush()
+
+	out.Reset()
+	out.sizes = nil
+	w.SetOutputThrottle(2, time.Microsecond)
+	fmt.Fprint(w, "beta\t2\n")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if want := "beta 2\n"; out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+	if want := []int{2, 2, 2, 1}; fmt.Sprint(out.sizes) != fmt.Sprint(want) {
+		t.Errorf("write sizes: got %v, want %v", out.sizes, want)
+	}
+}
+
+func TestFlushContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := chunkWriter{fn: cancel}
+	w := NewWriter(&out, 0, 8, 1, ' ', 0)
+	w.SetTerminal(tty{})
+	w.SetOutputThrottle(4, time.Hour)
+	fmt.Fprint(w, "alpha\t1\nbeta\t2\n")
+
+	// The flush is canceled during the pause following the first chunk.
+	if err := w.FlushContext(ctx); err != context.Canceled {
+		t.Errorf("FlushContext: got %v, want %v", err, context.Canceled)
+	}
+	if out.String() != "alph" {
+		t.Errorf("got %q, want %q", out.String(), "alph")
+	}
+	if _, err := fmt.Fprint(w, "x\n"); err != context.Canceled {
+		t.Errorf("Write after cancellation: got %v", err)
+	}
+
+	w.Reset()
+	w.SetOutputThrottle(0, 0)
+	fmt.Fprint(w, "a\tb\n")
+	if err := w.FlushContext(context.Background()); err != nil {
+		t.Errorf("FlushContext after Reset: %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package tabwriter
+
+import (
+	"fmt"
+	"io"
+)
+
+// SetTraceWriter sets a writer to which the writer logs the layout decisions
+// it makes, one per line: the width each block of cells in a column is sized
+// to, and every cell that is truncated or wrapped. The log helps explain why
+// a table looks the way it does, and is kept separate from the writer's
+// output. A nil writer disables logging.
+func (w *Writer) SetTraceWriter(trace io.Writer) {
+	w.trace = trace
+}
+
+// tracef logs a layout decision to the writer's trace writer, if any.
+func (w *Writer) tracef(format string, args ...interface{}) {
+	if w.trace != nil {
+		fmt.Fprintf(w.trace, "tabwriter: "+format+"\n", args...)
+	}
+}
@@ -0,0 +1,31 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestSetTraceWriter(t *testing.T) {
+	var b, trace bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetTraceWriter(&trace)
+	w.SetColumnSizing(0, SoftMax(4, 100))
+	w.SetColumnOverflow(0, OverflowTruncate)
+	fmt.Fprint(w, "alpha\tone\n")
+	fmt.Fprint(w, "b\ttwo\n")
+	w.Flush()
+
+	want := "alp… one\n" +
+		"b    two\n"
+	if b.String() != want {
+		t.Errorf("output: got:\n%q\nwant:\n%q", b.String(), want)
+	}
+
+	wantTrace := "tabwriter: flush: 2 lines\n" +
+		"tabwriter: column 0: truncated \"alpha\" to width 4\n" +
+		"tabwriter: column 0: sized 2 cells to width 5\n"
+	if trace.String() != wantTrace {
+		t.Errorf("trace: got:\n%s\nwant:\n%s", trace.String(), wantTrace)
+	}
+}
@@ -0,0 +1,38 @@
+package tabwriter
+
+import "bytes"
+
+// A LineTransformer transforms a line of output, without its terminating
+// newline, just before it is written to the writer's output.
+type LineTransformer func(line []byte) []byte
+
+// SetLineTransformers sets the transformers applied, in order, to every line
+// the writer outputs, including raw text, so that lines may be decorated with
+// log prefixes or syntax highlighting without wrapping the output in a writer
+// that can't see line boundaries. Transformers are applied after the line has
+// been aligned, so text they add does not affect alignment. Calling
+// SetLineTransformers with no transformers removes them.
+func (w *Writer) SetLineTransformers(transformers ...LineTransformer) {
+	w.transformers = append([]LineTransformer(nil), transformers...)
+}
+
+// transformLines applies the writer's line transformers to each line of text.
+func (w *Writer) transformLines(text []byte) []byte {
+	if len(w.transformers) == 0 {
+		return text
+	}
+
+	var out []byte
+	for len(text) > 0 {
+		line, rest, found := bytes.Cut(text, newline)
+		for _, t := range w.transformers {
+			line = t(line)
+		}
+		out = append(out, line...)
+		if found {
+			out = append(out, '\n')
+		}
+		text = rest
+	}
+	return out
+}
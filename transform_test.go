@@ -0,0 +1,27 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestSetLineTransformers(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetLineTransformers(
+		func(line []byte) []byte { return bytes.TrimRight(line, " ") },
+		func(line []byte) []byte { return append([]byte("log: "), line...) },
+	)
+	fmt.Fprint(w, "NAME\tSIZE\t\n")
+	w.WriteRaw([]byte("# sources\n"))
+	fmt.Fprint(w, "tabwriter.go\t11947\t\n")
+	w.Flush()
+
+	want := "log: NAME         SIZE\n" +
+		"log: # sources\n" +
+		"log: tabwriter.go 11947\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
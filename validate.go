@@ -0,0 +1,62 @@
+package tabwriter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the writer's settings for combinations that can't produce
+// sensible output, such as descriptions wrapped at a column before their
+// indentation, or tab padding with a bordered output format. It returns an
+// error describing every problem found, or nil if the settings are
+// consistent. Calling Validate before writing lets programs report
+// configuration mistakes instead of printing misaligned tables. The types of
+// columns, such as text in a column aligned with AlignDecimal, are checked
+// using the rows buffered since the last flush.
+func (w *Writer) Validate() error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("tabwriter: "+format, args...))
+	}
+
+	if w.padchar == '\t' && w.tabwidth <= 0 {
+		fail("tab padding requires a positive tab width, got %d", w.tabwidth)
+	}
+	for _, f := range []OutputFormat{w.outputFormat, w.fallbackFormat} {
+		if w.padchar == '\t' && (f == FormatBorder || f == FormatMarkdown) {
+			fail("tab padding can't be used with bordered or markdown output")
+			break
+		}
+	}
+
+	if d := w.formatDescription; d.wordwrap > 0 && d.wordwrap <= d.indent {
+		fail("descriptions wrap at column %d, before their indentation of %d", d.wordwrap, d.indent)
+	}
+	if r := w.formatRow; r.wrap > 0 && r.wrap <= w.measure([]byte(r.prefix)) {
+		fail("rows wrap at column %d, within their continuation prefix %q", r.wrap, r.prefix)
+	}
+
+	for col, c := range w.columns {
+		f := w.getFormat(col)
+		if c.wrap.width > 0 && c.wrap.width+f.padding < f.minwidth {
+			fail("column %d wraps at width %d, below its minimum width %d", col, c.wrap.width, f.minwidth-f.padding)
+		}
+		if c.maxWidth > 0 && c.maxWidth+f.padding < f.minwidth {
+			fail("column %d is truncated to width %d, below its minimum width %d", col, c.maxWidth, f.minwidth-f.padding)
+		}
+		if c.wrap.width > 0 && c.reserve > c.wrap.width {
+			fail("column %d reserves width %d, beyond its wrap width %d", col, c.reserve, c.wrap.width)
+		}
+		if c.padchar == '\t' {
+			fail("column %d pads with tabs, which only the writer's padchar can do", col)
+		}
+	}
+
+	for col, s := range w.InferSchema() {
+		if w.getFormat(col).flags&AlignDecimal != 0 && s.Type == TypeText {
+			fail("column %d aligns decimal separators but contains text", col)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,67 @@
+package tabwriter
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	if err := w.Validate(); err != nil {
+		t.Errorf("default settings: got error %v", err)
+	}
+
+	w = NewWriter(&b, 0, 8, 1, '\t', 0)
+	w.SetOutputFormat(FormatBorder)
+	w.SetDescriptionFormat(20, 10)
+	w.SetColumnFormat(1, 12, 1, 0)
+	w.SetColumnWrap(1, 8, BreakWord)
+	err := w.Validate()
+	if err == nil {
+		t.Fatal("invalid settings: got no error")
+	}
+
+	want := []string{
+		"tabwriter: tab padding can't be used with bordered or markdown output",
+		"tabwriter: descriptions wrap at column 10, before their indentation of 20",
+		"tabwriter: column 1 wraps at width 8, below its minimum width 11",
+	}
+	if err.Error() != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", err, strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateDecimal(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(1, 0, 1, AlignDecimal)
+	io.WriteString(w, "name\tsize\na\t1.5\nb\t12\n")
+	if err := w.Validate(); err != nil {
+		t.Errorf("numeric column: got error %v", err)
+	}
+
+	io.WriteString(w, "c\tlarge\n")
+	want := "tabwriter: column 1 aligns decimal separators but contains text"
+	if err := w.Validate(); err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+}
+
+func TestValidateMaxWidth(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnFormat(0, 8, 1, 0)
+	w.SetColumnMaxWidth(0, 7)
+	if err := w.Validate(); err != nil {
+		t.Errorf("maximum width at minimum: got error %v", err)
+	}
+
+	w.SetColumnMaxWidth(0, 4)
+	want := "tabwriter: column 0 is truncated to width 4, below its minimum width 7"
+	if err := w.Validate(); err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+}
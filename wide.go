@@ -0,0 +1,87 @@
+package tabwriter
+
+import "strings"
+
+// A WideLinePolicy determines how rows containing a cell too wide for the
+// writer's line width are output.
+type WideLinePolicy int
+
+const (
+	// WideLineAlign aligns wide rows like any other row, widening their
+	// columns for all of the rows sharing them. It is the default policy.
+	WideLineAlign WideLinePolicy = iota
+
+	// WideLinePassThrough outputs wide rows untouched, with their cells
+	// separated by single spaces instead of being aligned, so that they
+	// don't affect the width of any column.
+	WideLinePassThrough
+
+	// WideLineWrap outputs wide rows like WideLinePassThrough, but breaks
+	// their text at exactly the line width. Each broken line ends with a
+	// marker showing that the row continues on the next line.
+	WideLineWrap
+)
+
+// wideLineMarker ends each line of a row broken by WideLineWrap.
+const wideLineMarker = "↩"
+
+// SetWideLinePolicy sets how rows containing a cell wider than width
+// columns are output. A width of 0 uses the width of the writer's terminal,
+// and wide rows are not detected if the terminal's width is unknown. The
+// policy only applies to the text, border and markdown output formats.
+func (w *Writer) SetWideLinePolicy(width int, policy WideLinePolicy) {
+	w.wideWidth = width
+	w.widePolicy = policy
+}
+
+// wideLines replaces the buffered rows containing a cell too wide for the
+// writer's line width with raw text, according to the wide line policy.
+func (w *Writer) wideLines() {
+	width := w.wideWidth
+	if width <= 0 && w.terminal != nil {
+		width = w.terminal.Width()
+	}
+	switch {
+	case w.widePolicy == WideLineAlign || width <= 0:
+		return
+	case w.flushFormat != FormatText && !w.grid():
+		return
+	}
+
+	marker := w.measure([]byte(wideLineMarker))
+	for i := range w.lines {
+		l := &w.lines[i]
+		if l.raw.size > 0 || l.groups != nil || !w.wideLine(*l, width) {
+			continue
+		}
+
+		text := []byte(strings.ReplaceAll(strings.Join(w.cellTexts(*l), " "), "\n", " "))
+		var b strings.Builder
+		if w.widePolicy == WideLineWrap && width > marker {
+			segs := wrapText(text, width-marker, BreakHard, w.measure)
+			for k, s := range segs {
+				b.Write(text[s.start : s.start+s.size])
+				if k < len(segs)-1 {
+					b.WriteString(wideLineMarker)
+				}
+				b.WriteByte('\n')
+			}
+		} else {
+			b.Write(text)
+			b.WriteByte('\n')
+		}
+
+		w.tracef("line %d: output wide row as raw text", i)
+		*l = line{raw: w.newCell(b.String())}
+	}
+}
+
+// wideLine returns true if line l contains a cell wider than width columns.
+func (w *Writer) wideLine(l line, width int) bool {
+	for _, c := range l.cells {
+		if c.width > width {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestWideLinePolicy(t *testing.T) {
+	rows := []string{
+		"level\tmessage\tcode\n",
+		"info\tstarted\t0\n",
+		"error\t" + "stack:main.go:12:panic:nil-map-write\t" + "2\n",
+		"warn\tslow\t1\n",
+	}
+
+	tests := []struct {
+		policy WideLinePolicy
+		want   string
+	}{
+		{
+			WideLineAlign,
+			"level message                              code\n" +
+				"info  started                              0\n" +
+				"error stack:main.go:12:panic:nil-map-write 2\n" +
+				"warn  slow                                 1\n",
+		},
+		{
+			WideLinePassThrough,
+			"level message code\n" +
+				"info  started 0\n" +
+				"error stack:main.go:12:panic:nil-map-write 2\n" +
+				"warn  slow    1\n",
+		},
+		{
+			WideLineWrap,
+			"level message code\n" +
+				"info  started 0\n" +
+				"error stack:main.go:12:↩\n" +
+				"panic:nil-map-write 2\n" +
+				"warn  slow    1\n",
+		},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		w := NewWriter(&b, 0, 8, 1, ' ', 0)
+		w.SetTerminal(FixedWidth(24))
+		w.SetWideLinePolicy(0, test.policy)
+		for _, r := range rows {
+			fmt.Fprint(w, r)
+		}
+		w.Flush()
+
+		if b.String() != test.want {
+			t.Errorf("policy %d: got:\n%s\nwant:\n%s", test.policy, b.String(), test.want)
+		}
+	}
+}
+
+func TestWideLineWrapWideCluster(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetWideLinePolicy(2, WideLineWrap)
+	fmt.Fprint(w, "日本\n")
+	w.Flush()
+
+	want := "日↩\n" +
+		"本\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
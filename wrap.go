@@ -0,0 +1,197 @@
+package tabwriter
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// A BreakPolicy determines where the text of a wrapped column is broken
+// into lines.
+type BreakPolicy int
+
+const (
+	// BreakWord breaks lines at spaces. Words too long to fit on a line are
+	// broken wherever necessary.
+	BreakWord BreakPolicy = iota
+
+	// BreakHard breaks lines at exactly the column's wrap width.
+	BreakHard
+
+	// BreakPath breaks lines after '/' and '.' characters, which suits file
+	// paths, URLs and host names. Segments too long to fit on a line are
+	// broken wherever necessary.
+	BreakPath
+
+	// BreakNone never breaks lines. Text too long to fit in the column
+	// overflows it without widening the column.
+	BreakNone
+
+	// BreakHyphen breaks lines at spaces, like BreakWord, and after soft
+	// hyphens (U+00AD), where a visible hyphen is output. Words too long to
+	// fit on a line are broken wherever necessary.
+	BreakHyphen
+)
+
+// softHyphen marks a position where a word may be hyphenated.
+const softHyphen = '\u00ad'
+
+// wrap describes the settings to use when wrapping a column's cells.
+type wrap struct {
+	width  int         // Column width at which to wrap (0 = never)
+	policy BreakPolicy // Where to break lines
+}
+
+// A segment is one line of a wrapped cell's text.
+type segment struct {
+	start  int  // offset of the segment's text in the cell
+	size   int  // number of bytes in the segment
+	width  int  // number of columns the segment occupies
+	hyphen bool // the segment ends with a soft hyphen output as '-'
+}
+
+// wrapText breaks text into segments no wider than width, choosing break
+// points according to policy. The width of the text's grapheme clusters is
+// computed by measure.
+func wrapText(text []byte, width int, policy BreakPolicy, measure func([]byte) int) []segment {
+	var segs []segment
+
+	// Each segment runs from start to the most recent break opportunity at
+	// end. The following segment begins at next.
+	words := policy == BreakWord || policy == BreakHyphen
+	start, end, next, col := 0, -1, -1, 0
+	for p := 0; p < len(text); {
+		if n := escapeSize(text[p:]); n > 0 {
+			p += n
+			continue
+		}
+		r, size, _ := nextCluster(text[p:])
+		rw := measure(text[p : p+size])
+
+		if col+rw > width && !(words && r == ' ') {
+			switch {
+			case p == start:
+				// A cluster wider than the line occupies a line of its
+				// own, so that each segment consumes some text.
+				end, next = p+size, p+size
+			case end <= start:
+				// No break opportunity; break at the current rune.
+				end, next = p, p
+			}
+			s := newSegment(text, start, end, measure)
+			if policy == BreakHyphen && s.size > 0 {
+				if last, _ := utf8.DecodeLastRune(text[start:end]); last == softHyphen {
+					s.hyphen = true
+					s.width++
+				}
+			}
+			segs = append(segs, s)
+			start, end, p, col = next, -1, next, 0
+			continue
+		}
+
+		switch {
+		case words && r == ' ':
+			// Break before the first space of a run and resume after the
+			// last one.
+			if next != p {
+				end = p
+			}
+			next = p + size
+		case policy == BreakPath && (r == '/' || r == '.'):
+			end, next = p+size, p+size
+		case policy == BreakHyphen && r == softHyphen && col < width:
+			// Break after the soft hyphen if the visible hyphen fits.
+			end, next = p+size, p+size
+		}
+
+		col += rw
+		p += size
+	}
+
+	// A cluster wider than the line may have ended the text.
+	if start == len(text) && len(segs) > 0 {
+		return segs
+	}
+
+	// Drop trailing spaces from the final segment.
+	end = len(text)
+	if words && next == end && end > start {
+		for end > start && text[end-1] == ' ' {
+			end--
+		}
+	}
+	return append(segs, newSegment(text, start, end, measure))
+}
+
+// newSegment creates a segment spanning text[start:end].
+func newSegment(text []byte, start, end int, measure func([]byte) int) segment {
+	return segment{start: start, size: end - start, width: measure(text[start:end])}
+}
+
+// longestWord returns the width of the widest run of text containing no
+// break opportunity under policy. Text broken at exact widths by BreakHard is
+// treated as words separated by spaces. The width of the text's grapheme
+// clusters is computed by measure.
+func longestWord(text []byte, policy BreakPolicy, measure func([]byte) int) int {
+	longest, n := 0, 0
+	for p := 0; p < len(text); {
+		if n := escapeSize(text[p:]); n > 0 {
+			p += n
+			continue
+		}
+		r, size, _ := nextCluster(text[p:])
+		rw := measure(text[p : p+size])
+		p += size
+		switch {
+		case policy != BreakPath && r == ' ':
+			n = 0
+		case policy == BreakPath && (r == '/' || r == '.'),
+			policy == BreakHyphen && r == softHyphen:
+			longest = max(longest, n+1)
+			n = 0
+		default:
+			n += rw
+			longest = max(longest, n)
+		}
+	}
+	return longest
+}
+
+// wrapCell breaks cell c's text into segments no wider than width.
+func (w *Writer) wrapCell(c *cell, width int, policy BreakPolicy) {
+	c.segs = wrapText(w.cellText(*c), width, policy, w.measure)
+	c.width = 0
+	for _, s := range c.segs {
+		c.width = max(c.width, s.width)
+	}
+}
+
+// splitCell breaks the text of a multi-line cell into a segment per line.
+// Lines wider than the column's wrap width are wrapped onto additional
+// segments.
+func (w *Writer) splitCell(c *cell, wrap wrap) {
+	text := w.cellText(*c)
+	c.segs, c.width = nil, 0
+	for start := 0; start <= len(text); {
+		end := bytes.IndexByte(text[start:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += start
+		}
+
+		line := text[start:end]
+		width := w.measure(line)
+		if wrap.width > 0 && width > wrap.width && wrap.policy != BreakNone {
+			for _, s := range wrapText(line, wrap.width, wrap.policy, w.measure) {
+				s.start += start
+				c.segs = append(c.segs, s)
+				c.width = max(c.width, s.width)
+			}
+		} else {
+			c.segs = append(c.segs, segment{start: start, size: len(line), width: width})
+			c.width = max(c.width, width)
+		}
+		start = end + 1
+	}
+}
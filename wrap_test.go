@@ -0,0 +1,142 @@
+package tabwriter
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text   string
+		width  int
+		policy BreakPolicy
+		lines  []string
+	}{
+		{"the quick brown fox", 10, BreakWord, []string{"the quick", "brown fox"}},
+		{"the  quick   brown", 5, BreakWord, []string{"the", "quick", "brown"}},
+		{"abcdefghij klm", 4, BreakWord, []string{"abcd", "efgh", "ij", "klm"}},
+		{"trailing   ", 8, BreakWord, []string{"trailing"}},
+		{"the quick brown fox", 10, BreakHard, []string{"the quick ", "brown fox"}},
+		{"/usr/local/lib/go", 8, BreakPath, []string{"/usr/", "local/", "lib/go"}},
+		{"www.example.com", 10, BreakPath, []string{"www.", "example.", "com"}},
+		{"aaaaaaaaaa/b", 4, BreakPath, []string{"aaaa", "aaaa", "aa/b"}},
+		{"héllo wörld", 5, BreakWord, []string{"héllo", "wörld"}},
+		{"con\u00adfig\u00adu\u00adra\u00adtion", 7, BreakHyphen, []string{"con\u00adfig\u00ad", "u\u00adra\u00adtion"}},
+		{"soft\u00adhyphen text", 11, BreakHyphen, []string{"soft\u00adhyphen", "text"}},
+		{"a\u200bb\u200bc", 3, BreakHard, []string{"a\u200bb\u200bc"}},
+		{"\x1b[1mbold\x1b[0m text", 4, BreakWord, []string{"\x1b[1mbold\x1b[0m", "text"}},
+	}
+
+	for _, test := range tests {
+		var lines []string
+		for _, s := range wrapText([]byte(test.text), test.width, test.policy, displayWidth) {
+			line := test.text[s.start : s.start+s.size]
+			if s.width > test.width {
+				t.Errorf("wrapText(%q, %d): line %q too wide", test.text, test.width, line)
+			}
+			lines = append(lines, line)
+		}
+		if !reflect.DeepEqual(lines, test.lines) {
+			t.Errorf("wrapText(%q, %d) = %q, want %q", test.text, test.width, lines, test.lines)
+		}
+	}
+}
+
+func TestColumnWrap(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnWrap(1, 10, BreakWord)
+	w.SetColumnWrap(2, 8, BreakPath)
+	fmt.Fprint(w, "1\tshort\t/tmp\tok\n")
+	fmt.Fprint(w, "2\tsomewhat longer text\t/usr/local/bin\tok\n")
+	fmt.Fprint(w, "3\tx\t/etc/hosts\n")
+	w.Flush()
+
+	want := "1 short    /tmp   ok\n" +
+		"2 somewhat /usr/  ok\n" +
+		"  longer   local/\n" +
+		"  text     bin\n" +
+		"3 x        /etc/\n" +
+		"           hosts\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestColumnWrapWideCluster(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnWrap(0, 1, BreakHard)
+	fmt.Fprint(w, "日本\tx\n")
+	w.Flush()
+
+	want := "日 x\n" +
+		"本\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	segs := wrapText([]byte("ab"), 1, BreakWord, func(text []byte) int { return 3 * len(text) })
+	if len(segs) != 2 || segs[0].size != 1 || segs[1].size != 1 {
+		t.Errorf("wrapText with wide width func: got %v", segs)
+	}
+}
+
+func TestColumnMinContent(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnWrap(1, 20, BreakWord)
+	w.SetColumnMinContent(1, true)
+	fmt.Fprint(w, "a\tthe quick brown fox\tok\n")
+	fmt.Fprint(w, "b\tjumps over\tok\n")
+	w.Flush()
+
+	want := "a the   ok\n" +
+		"  quick\n" +
+		"  brown\n" +
+		"  fox\n" +
+		"b jumps ok\n" +
+		"  over\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	if n := longestWord([]byte("/usr/local/bin"), BreakPath, displayWidth); n != 6 {
+		t.Errorf("longestWord: got %d, want 6", n)
+	}
+}
+
+func TestColumnOverflow(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnWrap(0, 6, BreakNone)
+	fmt.Fprint(w, "a\tb\n")
+	fmt.Fprint(w, "overflowing\tc\n")
+	fmt.Fprint(w, "dd\te\n")
+	w.Flush()
+
+	want := "a      b\n" +
+		"overflowing c\n" +
+		"dd     e\n"
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestColumnWrapHyphen(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, 0, 8, 1, ' ', 0)
+	w.SetColumnWrap(1, 8, BreakHyphen)
+	fmt.Fprint(w, "-c\tcon\u00adfig\u00adu\u00adra\u00adtion\tfile\n")
+	fmt.Fprint(w, "-z\tzero\u200bwide\tok\n")
+	w.Flush()
+
+	want := "-c con\u00adfig\u00adu- file\n" +
+		"   ra\u00adtion\n" +
+		"-z zero\u200bwide ok\n"
+	if b.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}